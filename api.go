@@ -2,7 +2,7 @@ package notion
 
 import (
 	"context"
-	"time"
+	"io"
 )
 
 const apiVersion = "2021-05-13"
@@ -13,25 +13,62 @@ type API interface {
 	RetrieveDatabase(ctx context.Context, databaseID string) (*Database, error)
 	// QueryDatabase queries a database.
 	QueryDatabase(ctx context.Context, databaseID string, param QueryDatabaseParam) (results []*Page, nextCursor string, hasMore bool, err error)
+	// CreateDatabase creates a database as a subpage in the specified parent page.
+	// The keys of properties are the names of the property, exactly one title property is required.
+	CreateDatabase(ctx context.Context, parent Parent, title []*RichText, properties map[string]Property) (*Database, error)
+	// CreateDatabaseWithParam is same as CreateDatabase, but accepts more options like icon and cover.
+	CreateDatabaseWithParam(ctx context.Context, param CreateDatabaseParam) (*Database, error)
+	// UpdateDatabase updates the title and properties of a database.
+	// The keys of properties are the names or IDs of the property.
+	// A nil property removes the property, and a property with Name renames the property.
+	UpdateDatabase(ctx context.Context, databaseID string, title []*RichText, properties map[string]*Property) (*Database, error)
 	// ListDatabases lists databases.
 	ListDatabases(ctx context.Context, pageSize int32, startCursor string) (results []*Database, nextCursor string, hasMore bool, err error)
 	// RetrievePage retrieves a page.
 	RetrievePage(ctx context.Context, pageID string) (*Page, error)
 	// CreatePage creates a new page.
 	CreatePage(ctx context.Context, parent Parent, properties map[string]*PropertyValue, children ...*Block) (*Page, error)
+	// CreatePageWithParam is same as CreatePage, but accepts more options like icon and cover.
+	CreatePageWithParam(ctx context.Context, param CreatePageParam) (*Page, error)
+	// RetrievePageProperty retrieves the complete value of a page property,
+	// paginated values like relations and rollups are fetched through all pages.
+	RetrievePageProperty(ctx context.Context, pageID string, propertyID string) (*PropertyValue, error)
 	// UpdatePageProperties updates pages' properties.
 	// The keys of properties are the names or IDs of the property and the values are property values.
 	UpdatePageProperties(ctx context.Context, pageID string, properties map[string]*PropertyValue) (*Page, error)
+	// UpdatePage updates page's properties, archived state, icon and cover.
+	UpdatePage(ctx context.Context, pageID string, param UpdatePageParam) (*Page, error)
+	// ArchivePage archives (deletes) a page.
+	ArchivePage(ctx context.Context, pageID string) (*Page, error)
+	// RestorePage restores an archived page.
+	RestorePage(ctx context.Context, pageID string) (*Page, error)
+	// RetrieveBlock retrieves a block.
+	RetrieveBlock(ctx context.Context, blockID string) (*Block, error)
+	// UpdateBlock updates the content of a block according to its type.
+	// Only the content matching block.Type is sent, children cannot be updated by this method.
+	UpdateBlock(ctx context.Context, blockID string, block *Block) (*Block, error)
+	// DeleteBlock archives a block.
+	DeleteBlock(ctx context.Context, blockID string) (*Block, error)
 	// RetrieveBlockChildren retrieves child blocks of block.
 	RetrieveBlockChildren(ctx context.Context, blockID string, pageSize int32, startCursor string) (results []*Block, nextCursor string, hasMore bool, err error)
 	// AppendBlockChildren creates new child blocks.
 	AppendBlockChildren(ctx context.Context, blockID string, children ...*Block) error
+	// CreateComment creates a comment on a page or in an existing discussion thread.
+	CreateComment(ctx context.Context, param CreateCommentParam) (*Comment, error)
+	// ListComments lists unresolved comments of a page or block.
+	ListComments(ctx context.Context, blockID string, pageSize int32, startCursor string) (results []*Comment, nextCursor string, hasMore bool, err error)
 	// RetrieveUser retrieves user.
 	RetrieveUser(ctx context.Context, userID string) (*User, error)
+	// RetrieveMe retrieves the bot user associated with the token.
+	RetrieveMe(ctx context.Context) (*User, error)
 	// ListAllUsers lists all users.
 	ListAllUsers(ctx context.Context, pageSize int32, startCursor string) (results []*User, nextCursor string, hasMore bool, err error)
 	// Search searches objects.
 	Search(ctx context.Context, param SearchParam) (results []*Object, nextCursor string, hasMore bool, err error)
+	// DownloadFile downloads the content of file to w.
+	// Expired URL of hosted file is refreshed by retrieving the page or block containing the file,
+	// which requires the file was obtained from the page or block.
+	DownloadFile(ctx context.Context, file *File, w io.Writer) error
 }
 
 // SortDirection query result order.
@@ -55,12 +92,12 @@ type Sort struct {
 
 // SortByCreatedTime creates Sort to sort database by "created_time".
 func SortByCreatedTime(direction SortDirection) *Sort {
-	return &Sort{Direction: direction, Timestamp: "created_time"}
+	return &Sort{Direction: direction, Timestamp: TimestampCreatedTime}
 }
 
 // SortByLastEditedTime creates Sort to sort database by "last_edited_time".
 func SortByLastEditedTime(direction SortDirection) *Sort {
-	return &Sort{Direction: direction, Timestamp: "last_edited_time"}
+	return &Sort{Direction: direction, Timestamp: TimestampLastEditedTime}
 }
 
 // SortByProperty creates Sort to sort database by specified property.
@@ -91,6 +128,11 @@ type (
 		Files       *FilesFilterCondition       `json:"files,omitempty"`
 		Relation    *RelationFilterCondition    `json:"relation,omitempty"`
 		Formula     *FormulaFilterCondition     `json:"formula,omitempty"`
+		// Timestamp is the timestamp to filter, either "created_time" or "last_edited_time",
+		// with the condition in CreatedTime or LastEditedTime.
+		Timestamp      string               `json:"timestamp,omitempty"`
+		CreatedTime    *DateFilterCondition `json:"created_time,omitempty"`
+		LastEditedTime *DateFilterCondition `json:"last_edited_time,omitempty"`
 		// And is Compound filter.
 		And []*Filter `json:"and,omitempty"`
 		// Or is Compound filter.
@@ -98,33 +140,36 @@ type (
 	}
 
 	// TextFilterCondition applies to database properties of types "title", "rich_text", "url", "email", and "phone".
+	// Operands are pointers so that zero values, i.e. equals "", can be encoded.
 	TextFilterCondition struct {
-		Equals         string `json:"equals,omitempty"`
-		DoesNotEqual   string `json:"does_not_equal,omitempty"`
-		Contains       string `json:"contains,omitempty"`
-		DoesNotContain string `json:"does_not_contain,omitempty"`
-		StartsWith     string `json:"starts_with,omitempty"`
-		EndsWith       string `json:"ends_with,omitempty"`
-		IsEmpty        bool   `json:"is_empty,omitempty"`
-		IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+		Equals         *string `json:"equals,omitempty"`
+		DoesNotEqual   *string `json:"does_not_equal,omitempty"`
+		Contains       *string `json:"contains,omitempty"`
+		DoesNotContain *string `json:"does_not_contain,omitempty"`
+		StartsWith     *string `json:"starts_with,omitempty"`
+		EndsWith       *string `json:"ends_with,omitempty"`
+		IsEmpty        bool    `json:"is_empty,omitempty"`
+		IsNotEmpty     bool    `json:"is_not_empty,omitempty"`
 	}
 
 	// NumberFilterCondition applies to database properties of type "number".
+	// Operands are pointers so that zero values, i.e. greater than 0, can be encoded.
 	NumberFilterCondition struct {
-		Equals               float64 `json:"equals,omitempty"`
-		DoesNotEqual         float64 `json:"does_not_equal,omitempty"`
-		GreaterThan          float64 `json:"greater_than,omitempty"`
-		LessThan             float64 `json:"less_than,omitempty"`
-		GreaterThanOrEqualTo float64 `json:"greater_than_or_equal_to,omitempty"`
-		LessThanOrEqualTo    float64 `json:"less_than_or_equal_to,omitempty"`
-		IsEmpty              bool    `json:"is_empty,omitempty"`
-		IsNotEmpty           bool    `json:"is_not_empty,omitempty"`
+		Equals               *float64 `json:"equals,omitempty"`
+		DoesNotEqual         *float64 `json:"does_not_equal,omitempty"`
+		GreaterThan          *float64 `json:"greater_than,omitempty"`
+		LessThan             *float64 `json:"less_than,omitempty"`
+		GreaterThanOrEqualTo *float64 `json:"greater_than_or_equal_to,omitempty"`
+		LessThanOrEqualTo    *float64 `json:"less_than_or_equal_to,omitempty"`
+		IsEmpty              bool     `json:"is_empty,omitempty"`
+		IsNotEmpty           bool     `json:"is_not_empty,omitempty"`
 	}
 
 	// CheckboxFilterCondition applies to database properties of type "checkbox".
+	// Operands are pointers so that false can be encoded.
 	CheckboxFilterCondition struct {
-		Equals       bool `json:"equals,omitempty"`
-		DoesNotEqual bool `json:"does_not_equal,omitempty"`
+		Equals       *bool `json:"equals,omitempty"`
+		DoesNotEqual *bool `json:"does_not_equal,omitempty"`
 	}
 
 	// SelectFilterCondition applies to database properties of type "select".
@@ -145,26 +190,27 @@ type (
 
 	// DateFilterCondition applies to database properties of types "date", "created_time", and "last_edited_time".
 	DateFilterCondition struct {
-		Equals     *time.Time `json:"equals,omitempty"`
-		Before     *time.Time `json:"before,omitempty"`
-		After      *time.Time `json:"after,omitempty"`
-		OnOrBefore *time.Time `json:"on_or_before,omitempty"`
-		OnOrAfter  *time.Time `json:"on_or_after,omitempty"`
-		IsEmpty    bool       `json:"is_empty,omitempty"`
-		IsNotEmpty bool       `json:"is_not_empty,omitempty"`
-		PassWeek   *struct{}  `json:"pass_week,omitempty"`
-		PastYear   *struct{}  `json:"past_year,omitempty"`
-		NextWeek   *struct{}  `json:"next_week,omitempty"`
-		NextMonth  *struct{}  `json:"next_month,omitempty"`
-		NextYear   *struct{}  `json:"next_year,omitempty"`
+		Equals     *DateTime `json:"equals,omitempty"`
+		Before     *DateTime `json:"before,omitempty"`
+		After      *DateTime `json:"after,omitempty"`
+		OnOrBefore *DateTime `json:"on_or_before,omitempty"`
+		OnOrAfter  *DateTime `json:"on_or_after,omitempty"`
+		IsEmpty    bool      `json:"is_empty,omitempty"`
+		IsNotEmpty bool      `json:"is_not_empty,omitempty"`
+		PastWeek   *struct{} `json:"past_week,omitempty"`
+		PastMonth  *struct{} `json:"past_month,omitempty"`
+		PastYear   *struct{} `json:"past_year,omitempty"`
+		NextWeek   *struct{} `json:"next_week,omitempty"`
+		NextMonth  *struct{} `json:"next_month,omitempty"`
+		NextYear   *struct{} `json:"next_year,omitempty"`
 	}
 
-	// PeopleFilterCondition applies to database properties of types "date", "created_by", and "last_edited_by".
+	// PeopleFilterCondition applies to database properties of types "people", "created_by", and "last_edited_by".
 	PeopleFilterCondition struct {
 		Contains       string `json:"contains,omitempty"`
 		DoesNotContain string `json:"does_not_contain,omitempty"`
-		IsEmpty        string `json:"is_empty,omitempty"`
-		IsNotEmpty     string `json:"is_not_empty,omitempty"`
+		IsEmpty        bool   `json:"is_empty,omitempty"`
+		IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
 	}
 
 	// FilesFilterCondition applies to database properties of type "files".
@@ -190,6 +236,45 @@ type (
 	}
 )
 
+// CreateDatabaseParam is the param of CreateDatabaseWithParam.
+type CreateDatabaseParam struct {
+	Parent Parent      `json:"parent,omitempty"`
+	Title  []*RichText `json:"title,omitempty"`
+	// The keys of properties are the names of the property, exactly one title property is required.
+	Properties map[string]Property `json:"properties"`
+	Icon       *Icon               `json:"icon,omitempty"`
+	Cover      *Cover              `json:"cover,omitempty"`
+}
+
+// CreatePageParam is the param of CreatePageWithParam.
+type CreatePageParam struct {
+	Parent     Parent                    `json:"parent,omitempty"`
+	Properties map[string]*PropertyValue `json:"properties"`
+	Children   []*Block                  `json:"children,omitempty"`
+	Icon       *Icon                     `json:"icon,omitempty"`
+	Cover      *Cover                    `json:"cover,omitempty"`
+}
+
+// UpdatePageParam is the param of UpdatePage, the nil fields are not updated.
+type UpdatePageParam struct {
+	// The keys of properties are the names or IDs of the property and the values are property values.
+	Properties map[string]*PropertyValue `json:"properties,omitempty"`
+	// Archived archives or restores the page.
+	Archived *bool  `json:"archived,omitempty"`
+	Icon     *Icon  `json:"icon,omitempty"`
+	Cover    *Cover `json:"cover,omitempty"`
+}
+
+// CreateCommentParam is the param of CreateComment.
+// Either Parent or DiscussionID must be specified.
+type CreateCommentParam struct {
+	// Parent is the page to start a new discussion thread on.
+	Parent *Parent `json:"parent,omitempty"`
+	// DiscussionID is the discussion thread to reply to.
+	DiscussionID string      `json:"discussion_id,omitempty"`
+	RichText     []*RichText `json:"rich_text"`
+}
+
 type (
 	// SearchParam is param of Search.
 	SearchParam struct {
@@ -1,7 +1,9 @@
 package notion
 
 import (
+	"context"
 	"encoding/json"
+	"reflect"
 	"time"
 )
 
@@ -18,41 +20,298 @@ const (
 	BlockNumberedListItem BlockType = "numbered_list_item"
 	BlockToDo             BlockType = "to_do"
 	BlockToggle           BlockType = "toggle"
+	BlockCode             BlockType = "code"
+	BlockQuote            BlockType = "quote"
+	BlockCallout          BlockType = "callout"
+	BlockDivider          BlockType = "divider"
+	BlockImage            BlockType = "image"
+	BlockVideo            BlockType = "video"
+	BlockFile             BlockType = "file"
+	BlockPDF              BlockType = "pdf"
+	BlockBookmark         BlockType = "bookmark"
+	BlockEmbed            BlockType = "embed"
+	BlockEquation         BlockType = "equation"
+	BlockTableOfContents  BlockType = "table_of_contents"
+	BlockBreadcrumb       BlockType = "breadcrumb"
+	BlockColumnList       BlockType = "column_list"
+	BlockColumn           BlockType = "column"
+	BlockLinkToPage       BlockType = "link_to_page"
+	BlockSyncedBlock      BlockType = "synced_block"
+	BlockTemplate         BlockType = "template"
+	BlockTable            BlockType = "table"
+	BlockTableRow         BlockType = "table_row"
 	// BlockChildPage is not support appending currently.
-	BlockChildPage   BlockType = "child_page"
-	BlockUnsupported BlockType = "unsupported"
+	BlockChildPage BlockType = "child_page"
+	// BlockChildDatabase is not support appending currently.
+	BlockChildDatabase BlockType = "child_database"
+	BlockUnsupported   BlockType = "unsupported"
 )
 
 // Block object represents content within Notion.
 type Block struct {
-	Object           ObjectType `json:"object,omitempty"`
-	ID               string     `json:"id,omitempty"`
-	CreatedTime      time.Time  `json:"created_time,omitempty"`
-	LastEditedTime   time.Time  `json:"last_edited_time,omitempty"`
-	HasChildren      bool       `json:"has_children,omitempty"`
-	Type             BlockType  `json:"type,omitempty"`
-	Heading1         *Heading   `json:"heading_1,omitempty"`
-	Heading2         *Heading   `json:"heading_2,omitempty"`
-	Heading3         *Heading   `json:"heading_3,omitempty"`
-	Paragraph        *Paragraph `json:"paragraph,omitempty"`
-	BulletedListItem *ListItem  `json:"bulleted_list_item,omitempty"`
-	NumberedListItem *ListItem  `json:"numbered_list_item,omitempty"`
-	ToDo             *ToDo      `json:"to_do,omitempty"`
-	Toggle           *Toggle    `json:"toggle,omitempty"`
-	ChildPage        *ChildPage `json:"child_page,omitempty"`
+	Object           ObjectType       `json:"object,omitempty"`
+	ID               string           `json:"id,omitempty"`
+	CreatedTime      time.Time        `json:"created_time,omitempty"`
+	LastEditedTime   time.Time        `json:"last_edited_time,omitempty"`
+	HasChildren      bool             `json:"has_children,omitempty"`
+	Archived         bool             `json:"archived,omitempty"`
+	Type             BlockType        `json:"type,omitempty"`
+	Heading1         *Heading         `json:"heading_1,omitempty"`
+	Heading2         *Heading         `json:"heading_2,omitempty"`
+	Heading3         *Heading         `json:"heading_3,omitempty"`
+	Paragraph        *Paragraph       `json:"paragraph,omitempty"`
+	BulletedListItem *ListItem        `json:"bulleted_list_item,omitempty"`
+	NumberedListItem *ListItem        `json:"numbered_list_item,omitempty"`
+	ToDo             *ToDo            `json:"to_do,omitempty"`
+	Toggle           *Toggle          `json:"toggle,omitempty"`
+	Code             *Code            `json:"code,omitempty"`
+	Quote            *Quote           `json:"quote,omitempty"`
+	Callout          *Callout         `json:"callout,omitempty"`
+	Divider          *Divider         `json:"divider,omitempty"`
+	Image            *FileBlock       `json:"image,omitempty"`
+	Video            *FileBlock       `json:"video,omitempty"`
+	File             *FileBlock       `json:"file,omitempty"`
+	PDF              *FileBlock       `json:"pdf,omitempty"`
+	Bookmark         *Bookmark        `json:"bookmark,omitempty"`
+	Embed            *Embed           `json:"embed,omitempty"`
+	Equation         *Equation        `json:"equation,omitempty"`
+	TableOfContents  *TableOfContents `json:"table_of_contents,omitempty"`
+	Breadcrumb       *Breadcrumb      `json:"breadcrumb,omitempty"`
+	ColumnList       *ColumnList      `json:"column_list,omitempty"`
+	Column           *Column          `json:"column,omitempty"`
+	LinkToPage       *LinkToPage      `json:"link_to_page,omitempty"`
+	SyncedBlock      *SyncedBlock     `json:"synced_block,omitempty"`
+	Template         *Template        `json:"template,omitempty"`
+	Table            *Table           `json:"table,omitempty"`
+	TableRow         *TableRow        `json:"table_row,omitempty"`
+	ChildPage        *ChildPage       `json:"child_page,omitempty"`
+	ChildDatabase    *ChildDatabase   `json:"child_database,omitempty"`
+
+	// raw is the content of block types not recognized, which is kept for marshalling.
+	raw json.RawMessage
 }
 
 // MarshalJSON marshal Block to json and set Object to "block" automatically.
+// The content of unrecognized block type is marshalled as it was unmarshalled.
 func (b *Block) MarshalJSON() ([]byte, error) {
 	if b == nil {
 		return json.Marshal(nil)
 	}
 	b.Object = ObjectBlock
 	type Alias Block
-	return json.Marshal((*Alias)(b))
+	if b.raw == nil || b.knownContent() != nil {
+		return json.Marshal((*Alias)(b))
+	}
+	v, err := json.Marshal((*Alias)(b))
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(v, &m); err != nil {
+		return nil, err
+	}
+	m[string(b.Type)] = b.raw
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON unmarshal Block from json, and keeps the content of unrecognized block type.
+func (b *Block) UnmarshalJSON(bytes []byte) error {
+	type Alias Block
+	if err := json.Unmarshal(bytes, (*Alias)(b)); err != nil {
+		return err
+	}
+	b.raw = nil
+	for _, f := range []*FileBlock{b.Image, b.Video, b.File, b.PDF} {
+		if f != nil && f.Source != nil {
+			f.Source.owner = fileOwner{blockID: b.ID}
+		}
+	}
+	if b.Type == "" || b.knownContent() != nil {
+		return nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &m); err != nil {
+		return err
+	}
+	b.raw = m[string(b.Type)]
+	return nil
 }
 
-var _ json.Marshaler = &Block{}
+var (
+	_ json.Marshaler   = &Block{}
+	_ json.Unmarshaler = &Block{}
+)
+
+// Content returns the type-specific content of Block according to Type, returns nil if not present.
+// For block types not recognized, the raw json.RawMessage of the content is returned.
+func (b *Block) Content() interface{} {
+	if v := b.knownContent(); v != nil {
+		return v
+	}
+	if b != nil && b.raw != nil {
+		return b.raw
+	}
+	return nil
+}
+
+func (b *Block) knownContent() interface{} {
+	if b == nil {
+		return nil
+	}
+	switch b.Type {
+	case BlockParagraph:
+		return nilIfNil(b.Paragraph)
+	case BlockHeading1:
+		return nilIfNil(b.Heading1)
+	case BlockHeading2:
+		return nilIfNil(b.Heading2)
+	case BlockHeading3:
+		return nilIfNil(b.Heading3)
+	case BlockBulletedListItem:
+		return nilIfNil(b.BulletedListItem)
+	case BlockNumberedListItem:
+		return nilIfNil(b.NumberedListItem)
+	case BlockToDo:
+		return nilIfNil(b.ToDo)
+	case BlockToggle:
+		return nilIfNil(b.Toggle)
+	case BlockCode:
+		return nilIfNil(b.Code)
+	case BlockQuote:
+		return nilIfNil(b.Quote)
+	case BlockCallout:
+		return nilIfNil(b.Callout)
+	case BlockDivider:
+		return nilIfNil(b.Divider)
+	case BlockImage:
+		return nilIfNil(b.Image)
+	case BlockVideo:
+		return nilIfNil(b.Video)
+	case BlockFile:
+		return nilIfNil(b.File)
+	case BlockPDF:
+		return nilIfNil(b.PDF)
+	case BlockBookmark:
+		return nilIfNil(b.Bookmark)
+	case BlockEmbed:
+		return nilIfNil(b.Embed)
+	case BlockEquation:
+		return nilIfNil(b.Equation)
+	case BlockTableOfContents:
+		return nilIfNil(b.TableOfContents)
+	case BlockBreadcrumb:
+		return nilIfNil(b.Breadcrumb)
+	case BlockColumnList:
+		return nilIfNil(b.ColumnList)
+	case BlockColumn:
+		return nilIfNil(b.Column)
+	case BlockLinkToPage:
+		return nilIfNil(b.LinkToPage)
+	case BlockSyncedBlock:
+		return nilIfNil(b.SyncedBlock)
+	case BlockTemplate:
+		return nilIfNil(b.Template)
+	case BlockTable:
+		return nilIfNil(b.Table)
+	case BlockTableRow:
+		return nilIfNil(b.TableRow)
+	case BlockChildPage:
+		return nilIfNil(b.ChildPage)
+	case BlockChildDatabase:
+		return nilIfNil(b.ChildDatabase)
+	}
+	return nil
+}
+
+// Children returns the nested children of Block, which are only present when creating blocks or
+// retrieved by RetrieveBlockTree. Returns nil if the block type can't have children.
+func (b *Block) Children() []*Block {
+	switch c := b.knownContent().(type) {
+	case *Paragraph:
+		return c.Children
+	case *ListItem:
+		return c.Children
+	case *ToDo:
+		return c.Children
+	case *Toggle:
+		return c.Children
+	case *Quote:
+		return c.Children
+	case *Callout:
+		return c.Children
+	case *ColumnList:
+		return c.Children
+	case *Column:
+		return c.Children
+	case *SyncedBlock:
+		return c.Children
+	case *Template:
+		return c.Children
+	case *Table:
+		return c.Children
+	}
+	return nil
+}
+
+// SetChildren sets the nested children of Block, and reports whether the block type can have children.
+func (b *Block) SetChildren(children []*Block) bool {
+	switch c := b.knownContent().(type) {
+	case *Paragraph:
+		c.Children = children
+	case *ListItem:
+		c.Children = children
+	case *ToDo:
+		c.Children = children
+	case *Toggle:
+		c.Children = children
+	case *Quote:
+		c.Children = children
+	case *Callout:
+		c.Children = children
+	case *ColumnList:
+		c.Children = children
+	case *Column:
+		c.Children = children
+	case *SyncedBlock:
+		c.Children = children
+	case *Template:
+		c.Children = children
+	case *Table:
+		c.Children = children
+	default:
+		return false
+	}
+	return true
+}
+
+// RetrieveBlockTree retrieves all child blocks of block, and recursively retrieves children of
+// blocks with HasChildren, which are set by Block.SetChildren.
+// Child pages and child databases are not followed.
+func RetrieveBlockTree(ctx context.Context, api API, blockID string) ([]*Block, error) {
+	blocks, err := NewBlockChildrenIterator(api, blockID, 100).CollectAll(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range blocks {
+		if !b.HasChildren || b.Type == BlockChildPage || b.Type == BlockChildDatabase {
+			continue
+		}
+		children, err := RetrieveBlockTree(ctx, api, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		b.SetChildren(children)
+	}
+	return blocks, nil
+}
+
+// nilIfNil converts typed nil pointer to untyped nil.
+func nilIfNil(v interface{}) interface{} {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+	return v
+}
 
 // Paragraph is paragraph block.
 type Paragraph struct {
@@ -75,7 +334,8 @@ type ListItem struct {
 type ToDo struct {
 	Text     []*RichText `json:"text"`
 	Children []*Block    `json:"children,omitempty"`
-	Checked  bool        `json:"checked,omitempty"`
+	// Checked is always encoded, so that to-dos can be unchecked by UpdateBlock.
+	Checked bool `json:"checked"`
 }
 
 // Toggle is toggle item.
@@ -88,3 +348,141 @@ type Toggle struct {
 type ChildPage struct {
 	Title string `json:"title,omitempty"`
 }
+
+// ChildDatabase contains information of child database.
+type ChildDatabase struct {
+	Title string `json:"title,omitempty"`
+}
+
+// Code is code block.
+type Code struct {
+	Text    []*RichText `json:"text"`
+	Caption []*RichText `json:"caption,omitempty"`
+	// Language is the programming language of code, i.e. "go", "plain text".
+	Language string `json:"language,omitempty"`
+}
+
+// Quote is quote block.
+type Quote struct {
+	Text     []*RichText `json:"text"`
+	Children []*Block    `json:"children,omitempty"`
+	Color    Color       `json:"color,omitempty"`
+}
+
+// Callout is callout block.
+type Callout struct {
+	Text     []*RichText `json:"text"`
+	Icon     *Icon       `json:"icon,omitempty"`
+	Children []*Block    `json:"children,omitempty"`
+	Color    Color       `json:"color,omitempty"`
+}
+
+// Divider is divider block.
+type Divider struct{}
+
+// FileBlock is the common type of Image, Video, File and PDF block.
+type FileBlock struct {
+	// Source is the file of block, whose fields are inlined in the JSON of block.
+	Source  *File       `json:"-"`
+	Caption []*RichText `json:"caption,omitempty"`
+}
+
+// MarshalJSON marshal FileBlock to json with the fields of Source inlined.
+func (f FileBlock) MarshalJSON() ([]byte, error) {
+	type Alias File
+	return json.Marshal(struct {
+		*Alias
+		Caption []*RichText `json:"caption,omitempty"`
+	}{(*Alias)(f.Source), f.Caption})
+}
+
+// UnmarshalJSON unmarshal FileBlock from json, the fields of file are unmarshalled into Source.
+func (f *FileBlock) UnmarshalJSON(bytes []byte) error {
+	type Alias File
+	v := struct {
+		*Alias
+		Caption []*RichText `json:"caption"`
+	}{Alias: &Alias{}}
+	if err := json.Unmarshal(bytes, &v); err != nil {
+		return err
+	}
+	f.Source = (*File)(v.Alias)
+	f.Caption = v.Caption
+	return nil
+}
+
+var (
+	_ json.Marshaler   = FileBlock{}
+	_ json.Unmarshaler = &FileBlock{}
+)
+
+// Bookmark is bookmark block.
+type Bookmark struct {
+	URL     string      `json:"url,omitempty"`
+	Caption []*RichText `json:"caption,omitempty"`
+}
+
+// Embed is embed block.
+type Embed struct {
+	URL     string      `json:"url,omitempty"`
+	Caption []*RichText `json:"caption,omitempty"`
+}
+
+// TableOfContents is table of contents block.
+type TableOfContents struct {
+	Color Color `json:"color,omitempty"`
+}
+
+// Breadcrumb is breadcrumb block.
+type Breadcrumb struct{}
+
+// ColumnList is the container of Column blocks.
+type ColumnList struct {
+	Children []*Block `json:"children,omitempty"`
+}
+
+// Column is column block, which is only allowed as children of ColumnList.
+type Column struct {
+	Children []*Block `json:"children,omitempty"`
+}
+
+// LinkToPage is block linking to a page or database.
+type LinkToPage struct {
+	// Type is either ParentPage or ParentDatabase.
+	Type       ParentType `json:"type,omitempty"`
+	PageID     string     `json:"page_id,omitempty"`
+	DatabaseID string     `json:"database_id,omitempty"`
+}
+
+// SyncedBlock is synced block.
+// Original synced block has no SyncedFrom, and the duplicated ones refer to the original block by SyncedFrom.
+type SyncedBlock struct {
+	SyncedFrom *SyncedFrom `json:"synced_from"`
+	Children   []*Block    `json:"children,omitempty"`
+}
+
+// SyncedFrom is the reference to the original synced block.
+type SyncedFrom struct {
+	// Type is always be "block_id".
+	Type    string `json:"type,omitempty"`
+	BlockID string `json:"block_id,omitempty"`
+}
+
+// Template is template block.
+type Template struct {
+	Text     []*RichText `json:"text"`
+	Children []*Block    `json:"children,omitempty"`
+}
+
+// Table is table block, the rows are TableRow children.
+type Table struct {
+	TableWidth      int      `json:"table_width,omitempty"`
+	HasColumnHeader bool     `json:"has_column_header,omitempty"`
+	HasRowHeader    bool     `json:"has_row_header,omitempty"`
+	Children        []*Block `json:"children,omitempty"`
+}
+
+// TableRow is row of Table, each cell is a rich text array.
+type TableRow struct {
+	Cells [][]*RichText `json:"cells"`
+}
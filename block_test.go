@@ -1,10 +1,15 @@
 package notion
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBlock_MarshalJSON(t *testing.T) {
@@ -13,3 +18,154 @@ func TestBlock_MarshalJSON(t *testing.T) {
 	json.Unmarshal(b, &block)
 	assert.Equal(t, ObjectBlock, block.Object)
 }
+
+func TestBlock_Content(t *testing.T) {
+	assert.Nil(t, (*Block)(nil).Content())
+	assert.Nil(t, (&Block{Type: BlockToDo}).Content())
+	assert.Nil(t, (&Block{Type: BlockToDo, Paragraph: &Paragraph{}}).Content())
+	todo := &ToDo{Checked: true}
+	assert.Equal(t, todo, (&Block{Type: BlockToDo, ToDo: todo}).Content())
+}
+
+func TestClient_BlockCRUD(t *testing.T) {
+	var method, body string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/blocks/block", r.URL.Path)
+		method = r.Method
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		archived := r.Method == http.MethodDelete
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"object":   "block",
+			"id":       "block",
+			"type":     "to_do",
+			"archived": archived,
+			"to_do":    map[string]interface{}{"text": []interface{}{}, "checked": true},
+		})
+	}, Settings{})
+	ctx := context.Background()
+
+	block, err := client.RetrieveBlock(ctx, "block")
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodGet, method)
+	assert.True(t, block.ToDo.Checked)
+
+	block, err = client.UpdateBlock(ctx, "block", &Block{
+		Type:      BlockToDo,
+		Paragraph: &Paragraph{Text: []*RichText{}},
+		ToDo:      &ToDo{Text: []*RichText{{Type: RichTextText, Text: &Text{Content: "done"}}}, Checked: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, method)
+	assert.JSONEq(t, `{"to_do":{"text":[{"type":"text","text":{"content":"done"},"annotations":{}}],"checked":true}}`, body)
+	assert.Equal(t, BlockToDo, block.Type)
+
+	_, err = client.UpdateBlock(ctx, "block", &Block{
+		Type: BlockToDo,
+		ToDo: &ToDo{
+			Text:     []*RichText{},
+			Children: []*Block{{Type: BlockParagraph, Paragraph: &Paragraph{Text: []*RichText{}}}},
+		},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"to_do":{"text":[],"checked":false}}`, body)
+
+	_, err = client.UpdateBlock(ctx, "block", &Block{Type: BlockToDo})
+	assert.Error(t, err)
+
+	block, err = client.DeleteBlock(ctx, "block")
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, method)
+	assert.True(t, block.Archived)
+}
+
+func TestBlock_UnmarshalJSON(t *testing.T) {
+	raw := `[
+		{"object":"block","type":"code","code":{"text":[],"language":"go"}},
+		{"object":"block","type":"callout","callout":{"text":[],"icon":{"type":"emoji","emoji":"💡"}}},
+		{"object":"block","type":"divider","divider":{}},
+		{"object":"block","type":"image","image":{"type":"file","file":{"url":"https://s3/image.png","expiry_time":"2021-05-13T10:00:00.000Z"}}},
+		{"object":"block","type":"link_to_page","link_to_page":{"type":"page_id","page_id":"page"}},
+		{"object":"block","type":"synced_block","synced_block":{"synced_from":{"type":"block_id","block_id":"original"}}},
+		{"object":"block","type":"table_row","table_row":{"cells":[[{"type":"text","plain_text":"a"}],[]]}},
+		{"object":"block","type":"child_database","child_database":{"title":"db"}}
+	]`
+	var blocks []*Block
+	require.NoError(t, json.Unmarshal([]byte(raw), &blocks))
+	assert.Equal(t, "go", blocks[0].Code.Language)
+	assert.Equal(t, "💡", blocks[1].Callout.Icon.Emoji)
+	assert.NotNil(t, blocks[2].Divider)
+	assert.Equal(t, "https://s3/image.png", blocks[3].Image.Source.URL())
+	assert.Equal(t, "page", blocks[4].LinkToPage.PageID)
+	assert.Equal(t, "original", blocks[5].SyncedBlock.SyncedFrom.BlockID)
+	assert.Equal(t, "a", blocks[6].TableRow.Cells[0][0].PlainText)
+	assert.Equal(t, "db", blocks[7].ChildDatabase.Title)
+	for _, b := range blocks {
+		assert.NotNil(t, b.Content(), b.Type)
+		assert.Nil(t, b.raw, b.Type)
+	}
+}
+
+func TestFileBlock_JSON(t *testing.T) {
+	b, err := json.Marshal(&FileBlock{Source: NewExternalFile("", "https://example.com/cat.png")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"external","external":{"url":"https://example.com/cat.png"}}`, string(b))
+
+	var image FileBlock
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"external","external":{"url":"https://example.com/cat.png"},"caption":[{"plain_text":"cat"}]}`), &image))
+	assert.Equal(t, "https://example.com/cat.png", image.Source.URL())
+	assert.Equal(t, "cat", image.Caption[0].PlainText)
+
+	b, err = json.Marshal(&FileBlock{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(b))
+}
+
+func TestBlock_UnknownType(t *testing.T) {
+	raw := `{"object":"block","id":"block","type":"ai_block","ai_block":{"prompt":"hello","nested":{"a":[1,2]}}}`
+	var block Block
+	require.NoError(t, json.Unmarshal([]byte(raw), &block))
+	assert.Equal(t, BlockType("ai_block"), block.Type)
+	assert.JSONEq(t, `{"prompt":"hello","nested":{"a":[1,2]}}`, string(block.Content().(json.RawMessage)))
+
+	b, err := json.Marshal(&block)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"object":"block","id":"block","type":"ai_block",
+		"created_time":"0001-01-01T00:00:00Z","last_edited_time":"0001-01-01T00:00:00Z",
+		"ai_block":{"prompt":"hello","nested":{"a":[1,2]}}
+	}`, string(b))
+}
+
+func TestSyncedBlock_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(&SyncedBlock{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"synced_from":null}`, string(b))
+}
+
+func TestRetrieveBlockTree(t *testing.T) {
+	children := map[string][]map[string]interface{}{
+		"root": {
+			{"object": "block", "id": "a", "type": "bulleted_list_item", "has_children": true, "bulleted_list_item": map[string]interface{}{"text": []interface{}{}}},
+			{"object": "block", "id": "b", "type": "child_page", "has_children": true, "child_page": map[string]interface{}{"title": "Child"}},
+		},
+		"a": {
+			{"object": "block", "id": "c", "type": "paragraph", "paragraph": map[string]interface{}{"text": []interface{}{}}},
+		},
+	}
+	var requested []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/blocks/"), "/children")
+		requested = append(requested, id)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"object": "list", "results": children[id]})
+	}, Settings{})
+
+	blocks, err := RetrieveBlockTree(context.Background(), client, "root")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root", "a"}, requested)
+	require.Len(t, blocks, 2)
+	require.Len(t, blocks[0].Children(), 1)
+	assert.Equal(t, "c", blocks[0].Children()[0].ID)
+	assert.Nil(t, blocks[1].Children())
+	assert.False(t, blocks[1].SetChildren([]*Block{}))
+}
@@ -0,0 +1,163 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrCheckpointMismatch is returned when the stored checkpoint was created by a different query.
+var ErrCheckpointMismatch = errors.New("notion: checkpoint query mismatch")
+
+// Checkpoint records the progress of a paginated query.
+type Checkpoint struct {
+	// Cursor is the start cursor of the next page to fetch.
+	Cursor string `json:"cursor"`
+	// Query is the query parameters without start cursor, used to validate the checkpoint on resuming.
+	Query json.RawMessage `json:"query"`
+	// UpdatedTime is the time when the checkpoint is saved.
+	UpdatedTime time.Time `json:"updated_time"`
+}
+
+// CheckpointStore persists checkpoints by key.
+type CheckpointStore interface {
+	// Load loads the checkpoint of key, returns nil if not found.
+	Load(ctx context.Context, key string) (*Checkpoint, error)
+	// Save saves the checkpoint of key.
+	Save(ctx context.Context, key string, checkpoint *Checkpoint) error
+	// Delete deletes the checkpoint of key, it's no-op if not found.
+	Delete(ctx context.Context, key string) error
+}
+
+// FileCheckpointStore is a CheckpointStore which stores each checkpoint as a JSON file in a directory.
+type FileCheckpointStore struct {
+	dir string
+}
+
+var _ CheckpointStore = &FileCheckpointStore{}
+
+// NewFileCheckpointStore creates a FileCheckpointStore storing checkpoints in dir.
+func NewFileCheckpointStore(dir string) *FileCheckpointStore {
+	return &FileCheckpointStore{dir: dir}
+}
+
+func (s *FileCheckpointStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".json")
+}
+
+// Load implements CheckpointStore.Load.
+func (s *FileCheckpointStore) Load(_ context.Context, key string) (*Checkpoint, error) {
+	b, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(b, &checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Save implements CheckpointStore.Save.
+// The file is written to a temporary file and renamed, so that a crash never leaves a partial checkpoint.
+func (s *FileCheckpointStore) Save(_ context.Context, key string, checkpoint *Checkpoint) error {
+	b, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path(key))
+}
+
+// Delete implements CheckpointStore.Delete.
+func (s *FileCheckpointStore) Delete(_ context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ResumeQueryDatabase creates a PageIterator for API.QueryDatabase which resumes from the checkpoint stored by key.
+// The cursor is saved after each page is consumed, and the checkpoint is deleted once iteration finished.
+// ErrCheckpointMismatch is returned if the stored checkpoint was created by a different query.
+func ResumeQueryDatabase(ctx context.Context, api API, store CheckpointStore, key string, databaseID string, param QueryDatabaseParam) (*PageIterator, error) {
+	param.StartCursor = ""
+	query := struct {
+		DatabaseID string `json:"database_id"`
+		QueryDatabaseParam
+	}{DatabaseID: databaseID, QueryDatabaseParam: param}
+	it := NewQueryDatabaseIterator(api, databaseID, param)
+	if err := it.checkpoint(ctx, store, key, query); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// ResumeSearch creates an ObjectIterator for API.Search which resumes from the checkpoint stored by key.
+// The cursor is saved after each page is consumed, and the checkpoint is deleted once iteration finished.
+// ErrCheckpointMismatch is returned if the stored checkpoint was created by a different query.
+func ResumeSearch(ctx context.Context, api API, store CheckpointStore, key string, param SearchParam) (*ObjectIterator, error) {
+	param.StartCursor = ""
+	it := NewSearchIterator(api, param)
+	if err := it.checkpoint(ctx, store, key, param); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// checkpoint makes the iterator resume from and save progress to store.
+func (it *iterator) checkpoint(ctx context.Context, store CheckpointStore, key string, query interface{}) error {
+	q, err := json.Marshal(query)
+	if err != nil {
+		return err
+	}
+	checkpoint, err := store.Load(ctx, key)
+	if err != nil {
+		return err
+	}
+	if checkpoint != nil {
+		if !bytes.Equal(checkpoint.Query, q) {
+			return fmt.Errorf("%w: %s", ErrCheckpointMismatch, key)
+		}
+		it.cursor = checkpoint.Cursor
+	}
+
+	fetch := it.fetch
+	it.fetch = func(ctx context.Context, cursor string) (*List, error) {
+		// The previous page has been consumed when fetching the next one.
+		if cursor != "" {
+			err := store.Save(ctx, key, &Checkpoint{Cursor: cursor, Query: q, UpdatedTime: time.Now()})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return fetch(ctx, cursor)
+	}
+	it.finish = func(ctx context.Context) error {
+		return store.Delete(ctx, key)
+	}
+	return nil
+}
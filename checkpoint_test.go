@@ -0,0 +1,44 @@
+package notion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumeQueryDatabase(t *testing.T) {
+	client := newTestClient(t, pagedHandler(t, ObjectPage, 7, 3), Settings{})
+	store := NewFileCheckpointStore(t.TempDir())
+	ctx := context.Background()
+	param := QueryDatabaseParam{PageSize: 3, Sorts: []*Sort{SortByCreatedTime(DirectionAscending)}}
+
+	it, err := ResumeQueryDatabase(ctx, client, store, "job/1", "database", param)
+	require.NoError(t, err)
+	pages, err := it.CollectAll(ctx, 4)
+	require.NoError(t, err)
+	require.Len(t, pages, 4)
+
+	// the second page has not been consumed entirely, resume from it
+	checkpoint, err := store.Load(ctx, "job/1")
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+	assert.Equal(t, "3", checkpoint.Cursor)
+
+	_, err = ResumeQueryDatabase(ctx, client, store, "job/1", "database", QueryDatabaseParam{PageSize: 5})
+	assert.ErrorIs(t, err, ErrCheckpointMismatch)
+	_, err = ResumeQueryDatabase(ctx, client, store, "job/1", "other", param)
+	assert.ErrorIs(t, err, ErrCheckpointMismatch)
+
+	it, err = ResumeQueryDatabase(ctx, client, store, "job/1", "database", param)
+	require.NoError(t, err)
+	pages, err = it.CollectAll(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, pages, 4)
+	assert.Equal(t, "3", pages[0].ID)
+
+	checkpoint, err = store.Load(ctx, "job/1")
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httputil"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Client is implement of API.
@@ -18,6 +20,10 @@ type Client struct {
 	endpoint   string
 	debug      bool
 	httpclient *http.Client
+	retry      *RetryPolicy
+	limiter    RateLimiter
+	// schemas is nil unless queries are validated.
+	schemas *schemaCache
 }
 
 // Settings is configuration of Client.
@@ -25,6 +31,15 @@ type Settings struct {
 	Token      string
 	Endpoint   string
 	HTTPClient *http.Client
+	// RetryPolicy enables retrying failed requests. Nil disables retrying.
+	RetryPolicy *RetryPolicy
+	// RateLimiter limits the rate of requests. Nil disables client-side rate limiting.
+	// Share the same RateLimiter between Client values built from the same token.
+	RateLimiter RateLimiter
+	// ValidateQueries validates the filter and sorts of QueryDatabase against the database schema
+	// before sending, see ValidateQuery. Schemas are fetched on first use and cached,
+	// conditions on formulas are also checked against the result types seen in query results.
+	ValidateQueries bool
 }
 
 // NewClient creates a new API client.
@@ -33,6 +48,11 @@ func NewClient(settings Settings) API {
 		token:      settings.Token,
 		endpoint:   settings.Endpoint,
 		httpclient: settings.HTTPClient,
+		retry:      settings.RetryPolicy,
+		limiter:    settings.RateLimiter,
+	}
+	if settings.ValidateQueries {
+		c.schemas = &schemaCache{}
 	}
 	if c.endpoint == "" {
 		c.endpoint = "https://api.notion.com"
@@ -49,16 +69,81 @@ func (c *Client) RetrieveDatabase(ctx context.Context, databaseID string) (*Data
 	if err := c.request(ctx, http.MethodGet, "/v1/databases/"+databaseID, nil, &database); err != nil {
 		return nil, err
 	}
+	if c.schemas != nil {
+		c.schemas.put(databaseID, &database)
+	}
 	return &database, nil
 }
 
 // QueryDatabase implements API.QueryDatabase.
 func (c *Client) QueryDatabase(ctx context.Context, databaseID string, param QueryDatabaseParam) ([]*Page, string, bool, error) {
+	if err := c.validateQuery(ctx, databaseID, param); err != nil {
+		return nil, "", false, err
+	}
 	var result List
-	if err := c.request(ctx, http.MethodPost, "/v1/databases/"+databaseID+"/query", param, &result); err != nil {
+	if err := c.safeRequest(ctx, http.MethodPost, "/v1/databases/"+databaseID+"/query", param, &result); err != nil {
 		return nil, "", false, err
 	}
-	return result.Results.Pages(), result.NextCursor, result.HasMore, nil
+	pages := result.Results.Pages()
+	if c.schemas != nil {
+		c.schemas.observe(databaseID, pages)
+	}
+	return pages, result.NextCursor, result.HasMore, nil
+}
+
+// CreateDatabase implements API.CreateDatabase.
+func (c *Client) CreateDatabase(ctx context.Context, parent Parent, title []*RichText, properties map[string]Property) (*Database, error) {
+	return c.CreateDatabaseWithParam(ctx, CreateDatabaseParam{Parent: parent, Title: title, Properties: properties})
+}
+
+// CreateDatabaseWithParam implements API.CreateDatabaseWithParam.
+func (c *Client) CreateDatabaseWithParam(ctx context.Context, param CreateDatabaseParam) (*Database, error) {
+	var titles int
+	for name, property := range param.Properties {
+		if err := property.Validate(); err != nil {
+			return nil, fmt.Errorf("notion: invalid property %q: %w", name, err)
+		}
+		if property.Type == PropertyTitle {
+			titles++
+		}
+	}
+	if titles != 1 {
+		return nil, fmt.Errorf("notion: database requires exactly one title property, got %d", titles)
+	}
+	param.Parent.Type = ""
+	var database Database
+	if err := c.request(ctx, http.MethodPost, "/v1/databases", param, &database); err != nil {
+		return nil, err
+	}
+	return &database, nil
+}
+
+// UpdateDatabase implements API.UpdateDatabase.
+func (c *Client) UpdateDatabase(ctx context.Context, databaseID string, title []*RichText, properties map[string]*Property) (*Database, error) {
+	for name, property := range properties {
+		// Property without type only renames the property.
+		if property == nil || property.Type == "" {
+			continue
+		}
+		if err := property.Validate(); err != nil {
+			return nil, fmt.Errorf("notion: invalid property %q: %w", name, err)
+		}
+	}
+	body := struct {
+		Title      []*RichText          `json:"title,omitempty"`
+		Properties map[string]*Property `json:"properties,omitempty"`
+	}{
+		Title:      title,
+		Properties: properties,
+	}
+	var database Database
+	if err := c.request(ctx, http.MethodPatch, "/v1/databases/"+databaseID, body, &database); err != nil {
+		return nil, err
+	}
+	if c.schemas != nil {
+		c.schemas.put(databaseID, &database)
+	}
+	return &database, nil
 }
 
 // ListDatabases implements API.ListDatabases.
@@ -79,20 +164,63 @@ func (c *Client) RetrievePage(ctx context.Context, pageID string) (*Page, error)
 	return &page, nil
 }
 
+// RetrievePageProperty implements API.RetrievePageProperty.
+func (c *Client) RetrievePageProperty(ctx context.Context, pageID string, propertyID string) (*PropertyValue, error) {
+	var value *PropertyValue
+	var cursor string
+	for {
+		var raw json.RawMessage
+		// Property IDs returned by Notion are already escaped.
+		path := "/v1/pages/" + pageID + "/properties/" + propertyID
+		if err := c.request(ctx, http.MethodGet, path, nil, &raw, c.concatPagination(0, cursor)); err != nil {
+			return nil, err
+		}
+		var result propertyItemList
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, err
+		}
+		if result.Object != ObjectList {
+			return decodePropertyItem(raw)
+		}
+		if value == nil {
+			value = &PropertyValue{ID: propertyID}
+		}
+		if item := result.PropertyItem; item != nil {
+			value.ID = item.ID
+			value.Type = item.Type
+			// Aggregated rollups are final on the last page, while arrays are merged from the results.
+			if item.Rollup != nil {
+				rollup := *item.Rollup
+				if value.Rollup != nil {
+					rollup.Array = value.Rollup.Array
+				}
+				value.Rollup = &rollup
+			}
+		}
+		for _, r := range result.Results {
+			item, err := decodePropertyItem(r)
+			if err != nil {
+				return nil, err
+			}
+			value.merge(item)
+		}
+		if !result.HasMore || result.NextCursor == "" {
+			return value, nil
+		}
+		cursor = result.NextCursor
+	}
+}
+
 // CreatePage implements API.CreatePage.
 func (c *Client) CreatePage(ctx context.Context, parent Parent, properties map[string]*PropertyValue, children ...*Block) (*Page, error) {
-	parent.Type = ""
-	body := struct {
-		Parent     Parent                    `json:"parent,omitempty"`
-		Properties map[string]*PropertyValue `json:"properties"`
-		Children   []*Block                  `json:"children,omitempty"`
-	}{
-		Parent:     parent,
-		Properties: properties,
-		Children:   children,
-	}
+	return c.CreatePageWithParam(ctx, CreatePageParam{Parent: parent, Properties: properties, Children: children})
+}
+
+// CreatePageWithParam implements API.CreatePageWithParam.
+func (c *Client) CreatePageWithParam(ctx context.Context, param CreatePageParam) (*Page, error) {
+	param.Parent.Type = ""
 	var page Page
-	if err := c.request(ctx, http.MethodPost, "/v1/pages", body, &page); err != nil {
+	if err := c.request(ctx, http.MethodPost, "/v1/pages", param, &page); err != nil {
 		return nil, err
 	}
 	return &page, nil
@@ -112,6 +240,69 @@ func (c *Client) UpdatePageProperties(ctx context.Context, pageID string, proper
 	return &page, nil
 }
 
+// UpdatePage implements API.UpdatePage.
+func (c *Client) UpdatePage(ctx context.Context, pageID string, param UpdatePageParam) (*Page, error) {
+	var page Page
+	if err := c.request(ctx, http.MethodPatch, "/v1/pages/"+pageID, param, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ArchivePage implements API.ArchivePage.
+func (c *Client) ArchivePage(ctx context.Context, pageID string) (*Page, error) {
+	archived := true
+	return c.UpdatePage(ctx, pageID, UpdatePageParam{Archived: &archived})
+}
+
+// RestorePage implements API.RestorePage.
+func (c *Client) RestorePage(ctx context.Context, pageID string) (*Page, error) {
+	archived := false
+	return c.UpdatePage(ctx, pageID, UpdatePageParam{Archived: &archived})
+}
+
+// RetrieveBlock implements API.RetrieveBlock.
+func (c *Client) RetrieveBlock(ctx context.Context, blockID string) (*Block, error) {
+	var block Block
+	if err := c.request(ctx, http.MethodGet, "/v1/blocks/"+blockID, nil, &block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// UpdateBlock implements API.UpdateBlock.
+func (c *Client) UpdateBlock(ctx context.Context, blockID string, block *Block) (*Block, error) {
+	content := block.Content()
+	if content == nil {
+		return nil, errors.New("notion: missing block content matching block type")
+	}
+	// Children are not updated by this method, so they are stripped from the content.
+	b, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "children")
+	body := map[string]interface{}{string(block.Type): fields}
+	var updated Block
+	if err := c.request(ctx, http.MethodPatch, "/v1/blocks/"+blockID, body, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteBlock implements API.DeleteBlock.
+func (c *Client) DeleteBlock(ctx context.Context, blockID string) (*Block, error) {
+	var block Block
+	if err := c.request(ctx, http.MethodDelete, "/v1/blocks/"+blockID, nil, &block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
 // RetrieveBlockChildren implements API.RetrieveBlockChildren.
 func (c *Client) RetrieveBlockChildren(ctx context.Context, blockID string, pageSize int32, startCursor string) ([]*Block, string, bool, error) {
 	var result List
@@ -130,6 +321,37 @@ func (c *Client) AppendBlockChildren(ctx context.Context, blockID string, childr
 	return c.request(ctx, http.MethodPatch, "/v1/blocks/"+blockID+"/children", body, &block)
 }
 
+// CreateComment implements API.CreateComment.
+func (c *Client) CreateComment(ctx context.Context, param CreateCommentParam) (*Comment, error) {
+	if (param.Parent == nil) == (param.DiscussionID == "") {
+		return nil, errors.New("notion: exactly one of parent and discussion id is required")
+	}
+	if param.Parent != nil {
+		parent := *param.Parent
+		parent.Type = ""
+		param.Parent = &parent
+	}
+	var comment Comment
+	if err := c.request(ctx, http.MethodPost, "/v1/comments", param, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// ListComments implements API.ListComments.
+func (c *Client) ListComments(ctx context.Context, blockID string, pageSize int32, startCursor string) ([]*Comment, string, bool, error) {
+	var result List
+	withBlockID := func(req *http.Request) {
+		q := req.URL.Query()
+		q.Set("block_id", blockID)
+		req.URL.RawQuery = q.Encode()
+	}
+	if err := c.request(ctx, http.MethodGet, "/v1/comments", nil, &result, withBlockID, c.concatPagination(pageSize, startCursor)); err != nil {
+		return nil, "", false, err
+	}
+	return result.Results.Comments(), result.NextCursor, result.HasMore, nil
+}
+
 // RetrieveUser implements API.RetrieveUser.
 func (c *Client) RetrieveUser(ctx context.Context, userID string) (*User, error) {
 	var user User
@@ -139,6 +361,15 @@ func (c *Client) RetrieveUser(ctx context.Context, userID string) (*User, error)
 	return &user, nil
 }
 
+// RetrieveMe implements API.RetrieveMe.
+func (c *Client) RetrieveMe(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.request(ctx, http.MethodGet, "/v1/users/me", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // ListAllUsers implements API.ListAllUsers.
 func (c *Client) ListAllUsers(ctx context.Context, pageSize int32, startCursor string) ([]*User, string, bool, error) {
 	var result List
@@ -151,24 +382,126 @@ func (c *Client) ListAllUsers(ctx context.Context, pageSize int32, startCursor s
 // Search implements API.Search.
 func (c *Client) Search(ctx context.Context, param SearchParam) ([]*Object, string, bool, error) {
 	var result List
-	if err := c.request(ctx, http.MethodPost, "/v1/search", param, &result); err != nil {
+	if err := c.safeRequest(ctx, http.MethodPost, "/v1/search", param, &result); err != nil {
 		return nil, "", false, err
 	}
 	return result.Results, result.NextCursor, result.HasMore, nil
 }
 
+// DownloadFile implements API.DownloadFile.
+func (c *Client) DownloadFile(ctx context.Context, file *File, w io.Writer) error {
+	if file.Expired() {
+		if err := c.refreshFile(ctx, file); err != nil {
+			return err
+		}
+	}
+	fileURL := file.URL()
+	if fileURL == "" {
+		return errors.New("notion: missing file url")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return err
+	}
+	rsp, err := c.httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode >= 400 {
+		return fmt.Errorf("notion: download file: %s", rsp.Status)
+	}
+	_, err = io.Copy(w, rsp.Body)
+	return err
+}
+
+// refreshFile retrieves the owner of file to refresh its URL.
+func (c *Client) refreshFile(ctx context.Context, file *File) error {
+	owner := file.owner
+	var fresh *File
+	switch {
+	case owner.blockID != "":
+		block, err := c.RetrieveBlock(ctx, owner.blockID)
+		if err != nil {
+			return err
+		}
+		if f, ok := block.Content().(*FileBlock); ok {
+			fresh = f.Source
+		}
+	case owner.pageID != "" && owner.propertyID != "":
+		value, err := c.RetrievePageProperty(ctx, owner.pageID, owner.propertyID)
+		if err != nil {
+			return err
+		}
+		// Names of files may be duplicated, so the file is located by its position.
+		if owner.index < len(value.Files) {
+			fresh = value.Files[owner.index]
+		}
+	default:
+		return errors.New("notion: cannot refresh expired file without page or block")
+	}
+	if fresh == nil {
+		return errors.New("notion: file not found when refreshing")
+	}
+	*file = *fresh
+	file.owner = owner
+	return nil
+}
+
 func (c *Client) request(ctx context.Context, method string, path string, in interface{}, out interface{}, fns ...func(req *http.Request)) error {
-	var body io.Reader
+	return c.do(ctx, method, path, in, out, isIdempotent(method), fns...)
+}
+
+// safeRequest is same as request, but marks the request as safe to retry regardless of method.
+func (c *Client) safeRequest(ctx context.Context, method string, path string, in interface{}, out interface{}, fns ...func(req *http.Request)) error {
+	return c.do(ctx, method, path, in, out, true, fns...)
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, in interface{}, out interface{}, retryable bool, fns ...func(req *http.Request)) error {
+	var body []byte
 	if in != nil {
 		b, err := json.Marshal(in)
 		if err != nil {
 			return err
 		}
-		body = bytes.NewReader(b)
+		body = b
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+		err := c.send(ctx, method, path, body, out, fns...)
+		if e, ok := AsError(err); ok && e.Code == ErrCodeRateLimited && c.limiter != nil {
+			c.limiter.Throttled(e.RetryAfter)
+		}
+		if err == nil || !retryable || !c.retry.retryable(attempt, err) {
+			return err
+		}
+		delay := c.retry.delay(attempt, err)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method string, path string, body []byte, out interface{}, fns ...func(req *http.Request)) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
 	}
 
 	requestURL := strings.TrimSuffix(c.endpoint, "/") + path
-	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
 	if err != nil {
 		return err
 	}
@@ -195,7 +528,17 @@ func (c *Client) request(ctx context.Context, method string, path string, in int
 	if rsp.StatusCode >= 400 {
 		var e Error
 		if err := json.NewDecoder(rsp.Body).Decode(&e); err != nil {
-			return err
+			if rsp.StatusCode < 500 {
+				return err
+			}
+			// Gateways may respond server errors with non-JSON bodies, which are still retryable.
+			e = Error{Status: rsp.StatusCode, Code: ErrCodeInternalServerError, Message: rsp.Status}
+			if rsp.StatusCode == http.StatusServiceUnavailable {
+				e.Code = ErrCodeServiceUnavailable
+			}
+		}
+		if rsp.StatusCode == http.StatusTooManyRequests {
+			e.RetryAfter = parseRetryAfter(rsp.Header.Get("Retry-After"))
 		}
 		return &e
 	}
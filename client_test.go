@@ -0,0 +1,140 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, settings Settings) API {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	settings.Endpoint = srv.URL
+	return NewClient(settings)
+}
+
+func writeError(w http.ResponseWriter, status int, code ErrCode) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&Error{Status: status, Code: code, Message: string(code)})
+}
+
+func TestClient_Retry(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    3,
+		BaseDelay:      time.Millisecond,
+		RetryableCodes: []ErrCode{ErrCodeRateLimited, ErrCodeConflictError},
+	}
+
+	t.Run("retry with same body", func(t *testing.T) {
+		var bodies []string
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(b))
+			if len(bodies) < 3 {
+				w.Header().Set("Retry-After", "0")
+				writeError(w, http.StatusTooManyRequests, ErrCodeRateLimited)
+				return
+			}
+			_, _ = w.Write([]byte(`{"object":"list","results":[]}`))
+		}, Settings{RetryPolicy: policy})
+
+		_, _, _, err := client.Search(context.Background(), SearchParam{Query: "q"})
+		require.NoError(t, err)
+		require.Len(t, bodies, 3)
+		assert.Equal(t, bodies[0], bodies[2])
+	})
+
+	t.Run("give up after max attempts", func(t *testing.T) {
+		var count int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			count++
+			writeError(w, http.StatusConflict, ErrCodeConflictError)
+		}, Settings{RetryPolicy: policy})
+
+		_, err := client.RetrievePage(context.Background(), "page")
+		e, ok := AsError(err)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeConflictError, e.Code)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("not retry unsafe method", func(t *testing.T) {
+		var count int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			count++
+			writeError(w, http.StatusTooManyRequests, ErrCodeRateLimited)
+		}, Settings{RetryPolicy: policy})
+
+		_, err := client.CreatePage(context.Background(), NewPageParent("page"), nil)
+		require.Error(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("not retry other codes", func(t *testing.T) {
+		var count int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			count++
+			writeError(w, http.StatusNotFound, ErrCodeObjectNotFound)
+		}, Settings{RetryPolicy: policy})
+
+		_, err := client.RetrieveUser(context.Background(), "user")
+		require.Error(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("retry undecodable server errors", func(t *testing.T) {
+		var count int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			count++
+			if count == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = w.Write([]byte("<html>502 Bad Gateway</html>"))
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}, Settings{RetryPolicy: &RetryPolicy{
+			MaxAttempts:    2,
+			BaseDelay:      time.Millisecond,
+			RetryableCodes: []ErrCode{ErrCodeInternalServerError},
+		}})
+
+		_, err := client.RetrieveUser(context.Background(), "user")
+		e, ok := AsError(err)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusServiceUnavailable, e.Status)
+		assert.Equal(t, ErrCodeServiceUnavailable, e.Code)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("respect context deadline", func(t *testing.T) {
+		var count int
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			count++
+			w.Header().Set("Retry-After", "60")
+			writeError(w, http.StatusTooManyRequests, ErrCodeRateLimited)
+		}, Settings{RetryPolicy: policy})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err := client.RetrieveUser(ctx, "user")
+		e, ok := AsError(err)
+		require.True(t, ok)
+		assert.Equal(t, time.Minute, e.RetryAfter)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+	assert.Equal(t, time.Second, p.delay(1, nil))
+	assert.Equal(t, 2*time.Second, p.delay(2, nil))
+	assert.Equal(t, 3*time.Second, p.delay(3, nil))
+	assert.Equal(t, 5*time.Second, p.delay(1, &Error{RetryAfter: 5 * time.Second}))
+}
@@ -0,0 +1,401 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sorcererxw/go-notion"
+)
+
+// config is the config of generating code of a database.
+type config struct {
+	// Package is the package name of generated file.
+	Package string
+	// Type is the name of the row struct, which prefixes other identifiers.
+	Type string
+}
+
+// property is a database property with generated identifiers.
+type property struct {
+	notion.Property
+	name  string
+	ident string
+	// options is the options of select and multi-select properties.
+	options []option
+}
+
+type option struct {
+	name  string
+	ident string
+}
+
+// generate generates Go source of the row struct, constants and filter builders of db.
+func generate(db *notion.Database, cfg config) ([]byte, error) {
+	if cfg.Type == "" {
+		cfg.Type = identifier(plainText(db.Title))
+	}
+	if !isIdentifier(cfg.Type) {
+		return nil, fmt.Errorf("invalid type name %q", cfg.Type)
+	}
+	properties := properties(db)
+
+	g := &generator{cfg: cfg}
+	g.printf("// Code generated by notion-gen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", cfg.Package)
+	if usesTime(properties) {
+		g.printf("import (\n\"time\"\n\n\"github.com/sorcererxw/go-notion\"\n)\n\n")
+	} else {
+		g.printf("import \"github.com/sorcererxw/go-notion\"\n\n")
+	}
+
+	g.printf("// %sDatabaseID is the ID of database %q.\n", cfg.Type, plainText(db.Title))
+	g.printf("const %sDatabaseID = %s\n\n", cfg.Type, strconv.Quote(db.ID))
+
+	g.printf("// Property names of %s.\nconst (\n", cfg.Type)
+	for _, p := range properties {
+		g.printf("%sProp%s = %s\n", cfg.Type, p.ident, strconv.Quote(p.name))
+	}
+	g.printf(")\n\n")
+
+	for _, p := range properties {
+		if p.options == nil {
+			continue
+		}
+		typ := cfg.Type + p.ident
+		g.printf("// %s is an option of property %q.\ntype %s string\n\n", typ, p.name, typ)
+		g.printf("// Options of %s.\nconst (\n", typ)
+		for _, o := range p.options {
+			g.printf("%s%s %s = %s\n", typ, o.ident, typ, strconv.Quote(o.name))
+		}
+		g.printf(")\n\n")
+	}
+
+	g.printf("// %s is a page of database %q, which can be decoded by notion.UnmarshalPage\n", cfg.Type, plainText(db.Title))
+	g.printf("// and encoded by notion.MarshalProperties.\ntype %s struct {\n", cfg.Type)
+	for _, p := range properties {
+		if typ := g.fieldType(p); typ != "" {
+			g.printf("%s %s `notion:%s`\n", p.ident, typ, strconv.Quote(p.name+","+string(p.Type)))
+		}
+	}
+	g.printf("}\n\n")
+
+	filters := cfg.Type + "Filters"
+	g.printf("// %s builds typed filters of properties of %s.\n", filters, cfg.Type)
+	g.printf("var %s = struct {\n", filters)
+	for _, p := range properties {
+		if conditions(p.Type) != nil {
+			g.printf("%s %s\n", p.ident, g.filterType(p))
+		}
+	}
+	g.printf("}{}\n\n")
+	for _, p := range properties {
+		if conditions(p.Type) != nil {
+			g.filter(p)
+		}
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	cfg config
+	buf bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// fieldType returns the Go type of the field of property, empty if the property is not mapped.
+func (g *generator) fieldType(p property) string {
+	switch p.Type {
+	case notion.PropertyTitle, notion.PropertyRichText, notion.PropertyURL, notion.PropertyEmail,
+		notion.PropertyPhoneNumber, notion.PropertyCreatedBy, notion.PropertyLastEditedBy:
+		return "string"
+	case notion.PropertyNumber:
+		return "*float64"
+	case notion.PropertyCheckbox:
+		return "bool"
+	case notion.PropertySelect:
+		return g.cfg.Type + p.ident
+	case notion.PropertyMultiSelect:
+		return "[]" + g.cfg.Type + p.ident
+	case notion.PropertyDate:
+		return "*notion.Date"
+	case notion.PropertyCreatedTime, notion.PropertyLastEditedTime:
+		return "time.Time"
+	case notion.PropertyPeople, notion.PropertyRelation, notion.PropertyFile:
+		return "[]string"
+	}
+	// The result types of formulas and rollups are not present in schema.
+	return ""
+}
+
+func (g *generator) filterType(p property) string {
+	return lowerFirst(g.cfg.Type) + p.ident + "Filter"
+}
+
+// condition is a method of filter builder, which sets the field of filter condition of the same name.
+type condition struct {
+	method string
+	// param is the type of parameter, "option" for option type, empty for conditions without parameter.
+	param string
+	// value is the expression of value of field, v is the parameter and dateTime is the helper of dateTimeFunc.
+	value string
+}
+
+// conditions returns conditions of property type, nil if filter is not supported.
+func conditions(t notion.PropertyType) []condition {
+	switch t {
+	case notion.PropertyTitle, notion.PropertyRichText, notion.PropertyURL, notion.PropertyEmail, notion.PropertyPhoneNumber:
+		return []condition{
+			{"Equals", "string", "&v"},
+			{"DoesNotEqual", "string", "&v"},
+			{"Contains", "string", "&v"},
+			{"DoesNotContain", "string", "&v"},
+			{"StartsWith", "string", "&v"},
+			{"EndsWith", "string", "&v"},
+			{"IsEmpty", "", "true"},
+			{"IsNotEmpty", "", "true"},
+		}
+	case notion.PropertyNumber:
+		return []condition{
+			{"Equals", "float64", "&v"},
+			{"DoesNotEqual", "float64", "&v"},
+			{"GreaterThan", "float64", "&v"},
+			{"LessThan", "float64", "&v"},
+			{"GreaterThanOrEqualTo", "float64", "&v"},
+			{"LessThanOrEqualTo", "float64", "&v"},
+			{"IsEmpty", "", "true"},
+			{"IsNotEmpty", "", "true"},
+		}
+	case notion.PropertyCheckbox:
+		return []condition{
+			{"Equals", "bool", "&v"},
+			{"DoesNotEqual", "bool", "&v"},
+		}
+	case notion.PropertySelect:
+		return []condition{
+			{"Equals", "option", "string(v)"},
+			{"DoesNotEqual", "option", "string(v)"},
+			{"IsEmpty", "", "true"},
+			{"IsNotEmpty", "", "true"},
+		}
+	case notion.PropertyMultiSelect:
+		return []condition{
+			{"Contains", "option", "string(v)"},
+			{"DoesNotContain", "option", "string(v)"},
+			{"IsEmpty", "", "true"},
+			{"IsNotEmpty", "", "true"},
+		}
+	case notion.PropertyDate, notion.PropertyCreatedTime, notion.PropertyLastEditedTime:
+		return []condition{
+			{"Equals", "time.Time", "dateTime(v)"},
+			{"Before", "time.Time", "dateTime(v)"},
+			{"After", "time.Time", "dateTime(v)"},
+			{"OnOrBefore", "time.Time", "dateTime(v)"},
+			{"OnOrAfter", "time.Time", "dateTime(v)"},
+			{"IsEmpty", "", "true"},
+			{"IsNotEmpty", "", "true"},
+			{"PastWeek", "", "&struct{}{}"},
+			{"PastYear", "", "&struct{}{}"},
+			{"NextWeek", "", "&struct{}{}"},
+			{"NextMonth", "", "&struct{}{}"},
+			{"NextYear", "", "&struct{}{}"},
+		}
+	case notion.PropertyPeople, notion.PropertyCreatedBy, notion.PropertyLastEditedBy:
+		return []condition{
+			{"Contains", "string", "v"},
+			{"DoesNotContain", "string", "v"},
+		}
+	case notion.PropertyFile:
+		return []condition{
+			{"IsEmpty", "", "true"},
+			{"IsNotEmpty", "", "true"},
+		}
+	case notion.PropertyRelation:
+		return []condition{
+			{"Contains", "string", "v"},
+			{"DoesNotContain", "string", "v"},
+			{"IsEmpty", "", "true"},
+			{"IsNotEmpty", "", "true"},
+		}
+	}
+	return nil
+}
+
+// filterField returns the field name of Filter and the type of condition of property type.
+func filterField(t notion.PropertyType) (string, string) {
+	switch t {
+	case notion.PropertyTitle, notion.PropertyRichText, notion.PropertyURL, notion.PropertyEmail, notion.PropertyPhoneNumber:
+		return "Text", "TextFilterCondition"
+	case notion.PropertyNumber:
+		return "Number", "NumberFilterCondition"
+	case notion.PropertyCheckbox:
+		return "Checkbox", "CheckboxFilterCondition"
+	case notion.PropertySelect:
+		return "Select", "SelectFilterCondition"
+	case notion.PropertyMultiSelect:
+		return "MultiSelect", "MultiSelectFilterCondition"
+	case notion.PropertyDate, notion.PropertyCreatedTime, notion.PropertyLastEditedTime:
+		return "Date", "DateFilterCondition"
+	case notion.PropertyPeople, notion.PropertyCreatedBy, notion.PropertyLastEditedBy:
+		return "People", "PeopleFilterCondition"
+	case notion.PropertyFile:
+		return "Files", "FilesFilterCondition"
+	case notion.PropertyRelation:
+		return "Relation", "RelationFilterCondition"
+	}
+	return "", ""
+}
+
+func (g *generator) filter(p property) {
+	typ := g.filterType(p)
+	field, conditionType := filterField(p.Type)
+	g.printf("// %s builds filters of property %q.\ntype %s struct{}\n\n", typ, p.name, typ)
+	for _, c := range conditions(p.Type) {
+		param := ""
+		switch c.param {
+		case "":
+		case "option":
+			param = "v " + g.cfg.Type + p.ident
+		default:
+			param = "v " + c.param
+		}
+		g.printf("// %s creates filter of property %q.\n", c.method, p.name)
+		g.printf("func (%s) %s(%s) *notion.Filter {\n", typ, c.method, param)
+		g.printf("return &notion.Filter{Property: %sProp%s, %s: &notion.%s{%s: %s}}\n}\n\n",
+			g.cfg.Type, p.ident, field, conditionType, c.method, strings.ReplaceAll(c.value, "dateTime(", g.dateTimeFunc()+"("))
+	}
+	if p.Type == notion.PropertyDate || p.Type == notion.PropertyCreatedTime || p.Type == notion.PropertyLastEditedTime {
+		g.ensureDateTime()
+	}
+}
+
+// dateTimeFunc returns the name of the helper converting time.Time to *notion.DateTime,
+// which is prefixed by type to avoid conflicts between generated files in the same package.
+func (g *generator) dateTimeFunc() string {
+	return lowerFirst(g.cfg.Type) + "DateTime"
+}
+
+// ensureDateTime generates the helper of dateTimeFunc once.
+func (g *generator) ensureDateTime() {
+	name := g.dateTimeFunc()
+	if bytes.Contains(g.buf.Bytes(), []byte("func "+name+"(")) {
+		return
+	}
+	g.printf("func %s(t time.Time) *notion.DateTime {\nv := notion.NewDateTime(t)\nreturn &v\n}\n\n", name)
+}
+
+// usesTime reports whether the generated code uses package time.
+func usesTime(properties []property) bool {
+	for _, p := range properties {
+		switch p.Type {
+		case notion.PropertyDate, notion.PropertyCreatedTime, notion.PropertyLastEditedTime:
+			return true
+		}
+	}
+	return false
+}
+
+// properties returns properties of db sorted by name with unique identifiers.
+func properties(db *notion.Database) []property {
+	names := make([]string, 0, len(db.Properties))
+	for name := range db.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Option types are named by type name and property idents, which must not collide with other declarations.
+	idents := map[string]int{"DatabaseID": 1, "Filters": 1}
+	properties := make([]property, 0, len(names))
+	for _, name := range names {
+		p := property{Property: db.Properties[name], name: name, ident: unique(idents, identifier(name))}
+		var config *notion.SelectPropertyConfig
+		switch p.Type {
+		case notion.PropertySelect:
+			config = p.Select
+		case notion.PropertyMultiSelect:
+			config = p.MultiSelect
+		}
+		if p.Type == notion.PropertySelect || p.Type == notion.PropertyMultiSelect {
+			p.options = []option{}
+			optionIdents := map[string]int{}
+			if config != nil {
+				for _, o := range config.Options {
+					p.options = append(p.options, option{name: o.Name, ident: unique(optionIdents, identifier(o.Name))})
+				}
+			}
+		}
+		properties = append(properties, p)
+	}
+	return properties
+}
+
+// unique returns ident, or ident with numeric suffix if it's used.
+func unique(used map[string]int, ident string) string {
+	used[ident]++
+	if n := used[ident]; n > 1 {
+		return ident + strconv.Itoa(n)
+	}
+	return ident
+}
+
+var initialisms = map[string]string{"id": "ID", "url": "URL", "api": "API", "http": "HTTP", "https": "HTTPS"}
+
+// identifier converts name to exported Go identifier in camel case, i.e. "due date" to "DueDate".
+func identifier(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if s, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(s)
+			continue
+		}
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	ident := b.String()
+	if ident == "" {
+		return "X"
+	}
+	if r := []rune(ident)[0]; !unicode.IsLetter(r) || !unicode.IsUpper(r) {
+		ident = "X" + ident
+	}
+	return ident
+}
+
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+func plainText(texts []*notion.RichText) string {
+	var b strings.Builder
+	for _, t := range texts {
+		b.WriteString(t.PlainText)
+	}
+	return b.String()
+}
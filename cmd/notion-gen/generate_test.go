@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sorcererxw/go-notion"
+)
+
+func loadTestDatabase(t *testing.T) *notion.Database {
+	b, err := os.ReadFile("testdata/database.json")
+	require.NoError(t, err)
+	var db notion.Database
+	require.NoError(t, json.Unmarshal(b, &db))
+	return &db
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generate(loadTestDatabase(t), config{Package: "tasks"})
+	require.NoError(t, err)
+	code := string(src)
+
+	assert.Contains(t, code, `TasksDatabaseID = "a1b2c3"`)
+	assert.Contains(t, code, `TasksPropDueDate      = "Due date"`)
+	assert.Contains(t, code, `TasksPropX1stReviewer = "1st reviewer"`)
+	assert.Contains(t, code, `TasksStatusInProgress TasksStatus = "In progress"`)
+	assert.Contains(t, code, `TasksTagsAPI  TasksTags = "api"`)
+	assert.Contains(t, code, `TasksTagsAPI2 TasksTags = "API"`)
+	assert.Regexp(t, "Status +TasksStatus +`notion:\"Status,select\"`", code)
+	assert.Regexp(t, "Tags +\\[\\]TasksTags +`notion:\"Tags,multi_select\"`", code)
+	assert.NotContains(t, code, "`notion:\"Score,formula\"`")
+	assert.Contains(t, code, "func (tasksStatusFilter) Equals(v TasksStatus) *notion.Filter {\n"+
+		"\treturn &notion.Filter{Property: TasksPropStatus, Select: &notion.SelectFilterCondition{Equals: string(v)}}\n}")
+
+	checkGenerated(t, src)
+}
+
+// checkGenerated checks the generated code compiles against the current package.
+func checkGenerated(t *testing.T, src []byte) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "tasks_gen.go", src, 0)
+	require.NoError(t, err)
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("tasks", fset, []*ast.File{f}, nil)
+	require.NoError(t, err)
+}
+
+func TestGenerate_ReservedNames(t *testing.T) {
+	db := &notion.Database{
+		ID:    "a1b2c3",
+		Title: []*notion.RichText{{PlainText: "Tasks"}},
+		Properties: map[string]notion.Property{
+			"Name":        notion.NewTitleProperty(),
+			"Filters":     notion.NewSelectProperty(),
+			"Database ID": notion.NewSelectProperty(),
+		},
+	}
+	src, err := generate(db, config{Package: "tasks"})
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "type TasksFilters2 string")
+	assert.Contains(t, string(src), "type TasksDatabaseID2 string")
+	checkGenerated(t, src)
+}
+
+func TestGenerate_TypeName(t *testing.T) {
+	src, err := generate(loadTestDatabase(t), config{Package: "tasks", Type: "Task"})
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "type Task struct {")
+
+	_, err = generate(loadTestDatabase(t), config{Package: "tasks", Type: "my-task"})
+	assert.Error(t, err)
+}
+
+func TestIdentifier(t *testing.T) {
+	for name, ident := range map[string]string{
+		"Due date":      "DueDate",
+		"doc url":       "DocURL",
+		"1st":           "X1st",
+		"---":           "X",
+		"状态":            "X状态",
+		"already_Camel": "AlreadyCamel",
+	} {
+		assert.Equal(t, ident, identifier(name), name)
+	}
+}
@@ -0,0 +1,91 @@
+// Command notion-gen generates a typed Go row struct, property name and select option constants,
+// and typed filter builders from the schema of a Notion database.
+//
+// The schema is retrieved from Notion with a token:
+//
+//	NOTION_TOKEN=secret notion-gen -database <database_id> -package tasks -type Task -o task_gen.go
+//
+// or read from a saved JSON of database object:
+//
+//	notion-gen -schema database.json -package tasks -type Task -o task_gen.go
+//
+// Regenerating the file after the schema changed makes the usages of renamed or
+// removed properties and options fail to compile.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sorcererxw/go-notion"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "notion-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		token      = flag.String("token", os.Getenv("NOTION_TOKEN"), "Notion integration token, defaults to $NOTION_TOKEN")
+		databaseID = flag.String("database", "", "ID of the database to retrieve the schema")
+		schema     = flag.String("schema", "", "path of the saved JSON of database object, instead of retrieving")
+		save       = flag.String("save", "", "path to save the JSON of retrieved database object")
+		pkg        = flag.String("package", "main", "package name of the generated file")
+		typ        = flag.String("type", "", "name of the row struct, defaults to the title of database")
+		output     = flag.String("o", "", "path of the generated file, defaults to stdout")
+	)
+	flag.Parse()
+
+	db, err := loadDatabase(*token, *databaseID, *schema)
+	if err != nil {
+		return err
+	}
+	if *save != "" {
+		b, err := json.MarshalIndent(db, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*save, b, 0o644); err != nil {
+			return err
+		}
+	}
+
+	src, err := generate(db, config{Package: *pkg, Type: *typ})
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*output, src, 0o644)
+}
+
+func loadDatabase(token, databaseID, schema string) (*notion.Database, error) {
+	if schema != "" {
+		b, err := os.ReadFile(schema)
+		if err != nil {
+			return nil, err
+		}
+		var db notion.Database
+		if err := json.Unmarshal(b, &db); err != nil {
+			return nil, fmt.Errorf("decode schema: %w", err)
+		}
+		return &db, nil
+	}
+	if databaseID == "" {
+		return nil, errors.New("either -database or -schema is required")
+	}
+	if token == "" {
+		return nil, errors.New("-token or $NOTION_TOKEN is required to retrieve database")
+	}
+	client := notion.NewClient(notion.Settings{Token: token})
+	return client.RetrieveDatabase(context.Background(), databaseID)
+}
@@ -0,0 +1,572 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	dateType     = reflect.TypeOf(Date{})
+	dateTimeType = reflect.TypeOf(DateTime{})
+)
+
+// codecField is a struct field mapped to a page property by struct tag.
+type codecField struct {
+	index        []int
+	name         string
+	propertyType PropertyType
+	// explicit reports whether the property type is given by tag rather than inferred.
+	explicit bool
+}
+
+var codecFieldsCache sync.Map // map[reflect.Type][]codecField
+
+// codecFields returns the fields of struct type t tagged with `notion:"Property Name,type"`,
+// fields of untagged embedded structs are included.
+func codecFields(t reflect.Type) ([]codecField, error) {
+	if fields, ok := codecFieldsCache.Load(t); ok {
+		return fields.([]codecField), nil
+	}
+	var fields []codecField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("notion")
+		if !ok {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				embedded, err := codecFields(f.Type)
+				if err != nil {
+					return nil, err
+				}
+				for _, e := range embedded {
+					e.index = append([]int{i}, e.index...)
+					fields = append(fields, e)
+				}
+			}
+			continue
+		}
+		if tag == "-" {
+			continue
+		}
+		if f.PkgPath != "" {
+			return nil, fmt.Errorf("notion: unexported field %s.%s is tagged", t, f.Name)
+		}
+		// The type follows the last comma, so names containing commas require a type.
+		name, propertyType := tag, PropertyType("")
+		if i := strings.LastIndex(tag, ","); i >= 0 {
+			name, propertyType = tag[:i], PropertyType(tag[i+1:])
+			if !knownPropertyType(propertyType) {
+				return nil, fmt.Errorf("notion: field %s.%s has unknown property type %q, "+
+					"names containing commas must be followed by a type", t, f.Name, propertyType)
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("notion: field %s.%s has no property name", t, f.Name)
+		}
+		explicit := propertyType != ""
+		if !explicit {
+			propertyType = inferPropertyType(f.Type)
+		}
+		if propertyType == "" {
+			return nil, fmt.Errorf("notion: field %s.%s of %s needs property type", t, f.Name, f.Type)
+		}
+		fields = append(fields, codecField{index: []int{i}, name: name, propertyType: propertyType, explicit: explicit})
+	}
+	codecFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+// knownPropertyType reports whether t is one of the PropertyType enums.
+func knownPropertyType(t PropertyType) bool {
+	for _, c := range (&Property{}).configs() {
+		if c.typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// inferPropertyType infers property type from the Go type of field, returns empty if it's ambiguous.
+func inferPropertyType(t reflect.Type) PropertyType {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType, dateType, dateTimeType:
+		return PropertyDate
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return PropertyCheckbox
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return PropertyNumber
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return PropertyMultiSelect
+		}
+	}
+	return ""
+}
+
+func structValue(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}, errors.New("notion: nil value")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("notion: %s is not a struct", rv.Type())
+	}
+	return rv, nil
+}
+
+// UnmarshalPage decodes properties of page into the struct pointed by v, according to struct tags like:
+//
+//	type Task struct {
+//		Name     string     `notion:"Name,title"`
+//		Status   string     `notion:"Status,select"`
+//		Tags     []string   `notion:"Tags,multi_select"`
+//		Estimate *float64   `notion:"Estimate,number"`
+//		Due      *time.Time `notion:"Due,date"`
+//		Owners   []string   `notion:"Owners,people"`
+//	}
+//
+// The type in tag is optional unless it's ambiguous like for strings, it's checked against the type of the property when given.
+// The type follows the last comma of tag, so names containing commas require a type, e.g. `notion:"Cost, USD,number"`.
+// Texts are decoded into strings, numbers into integers and floats, checkboxes into bools,
+// dates into time.Time, Date or DateTime, select into string, multi-select into []string,
+// and relations, people and files into []string of page IDs, user IDs and URLs.
+// Values of formulas and rollups are decoded according to their result types.
+// Pointer fields are set to nil if the property is empty, properties absent in page are left untouched.
+func UnmarshalPage(page *Page, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("notion: UnmarshalPage requires a non-nil pointer")
+	}
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+	fields, err := codecFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		value, ok := page.Properties[f.name]
+		if !ok {
+			continue
+		}
+		if f.explicit && value.Type != f.propertyType {
+			return fmt.Errorf("notion: property %q is %s, not %s", f.name, value.Type, f.propertyType)
+		}
+		if err := decodePropertyValue(&value, rv.FieldByIndex(f.index)); err != nil {
+			return fmt.Errorf("notion: decode property %q: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func decodePropertyValue(v *PropertyValue, dst reflect.Value) error {
+	if dst.Kind() == reflect.Ptr {
+		if v.empty() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		elem := reflect.New(dst.Type().Elem())
+		if err := decodePropertyValue(v, elem.Elem()); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	unsupported := fmt.Errorf("%s value can't be decoded into %s", v.Type, dst.Type())
+	switch dst.Type() {
+	case timeType, dateType, dateTimeType:
+		date, ok := v.date()
+		if !ok {
+			return unsupported
+		}
+		if date == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		switch dst.Type() {
+		case timeType:
+			dst.Set(reflect.ValueOf(date.Start.Time))
+		case dateType:
+			dst.Set(reflect.ValueOf(*date))
+		default:
+			dst.Set(reflect.ValueOf(date.Start))
+		}
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		s, ok := v.string()
+		if !ok {
+			return unsupported
+		}
+		dst.SetString(s)
+	case reflect.Bool:
+		b, ok := v.bool()
+		if !ok {
+			return unsupported
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := v.number()
+		if !ok {
+			return unsupported
+		}
+		if n != math.Trunc(n) {
+			return fmt.Errorf("%v is not an integer of %s", n, dst.Type())
+		}
+		if dst.OverflowInt(int64(n)) {
+			return fmt.Errorf("%v overflows %s", n, dst.Type())
+		}
+		dst.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := v.number()
+		if !ok {
+			return unsupported
+		}
+		if n != math.Trunc(n) {
+			return fmt.Errorf("%v is not an integer of %s", n, dst.Type())
+		}
+		if n < 0 || dst.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%v overflows %s", n, dst.Type())
+		}
+		dst.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		n, ok := v.number()
+		if !ok {
+			return unsupported
+		}
+		dst.SetFloat(n)
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() != reflect.String {
+			return unsupported
+		}
+		strs, ok := v.strings()
+		if !ok {
+			return unsupported
+		}
+		s := reflect.MakeSlice(dst.Type(), len(strs), len(strs))
+		for i, str := range strs {
+			s.Index(i).SetString(str)
+		}
+		dst.Set(s)
+	default:
+		return unsupported
+	}
+	return nil
+}
+
+// empty reports whether the property value is empty, which is decoded to nil pointer.
+func (v *PropertyValue) empty() bool {
+	switch v.Type {
+	case PropertyTitle:
+		return len(v.Title) == 0
+	case PropertyRichText:
+		return len(v.RichText) == 0
+	case PropertyNumber:
+		return v.null
+	case PropertySelect:
+		return v.Select == nil
+	case PropertyDate:
+		return v.Date == nil
+	case PropertyURL:
+		return v.URL == ""
+	case PropertyEmail:
+		return v.Email == ""
+	case PropertyPhoneNumber:
+		return v.PhoneNumber == ""
+	case PropertyFormula:
+		return v.Formula == nil || v.Formula.Type == FormulaValueDate && v.Formula.Date == nil
+	case PropertyRollup:
+		return v.Rollup == nil || v.Rollup.Type == RolluoValueDate && v.Rollup.Date == nil
+	case PropertyCreatedBy:
+		return v.CreatedBy == nil
+	case PropertyLastEditedBy:
+		return v.LastEditedBy == nil
+	case PropertyCreatedTime:
+		return v.CreatedTime == nil
+	case PropertyLastEditedTime:
+		return v.LastEditedTime == nil
+	}
+	return false
+}
+
+func (v *PropertyValue) string() (string, bool) {
+	switch v.Type {
+	case PropertyTitle:
+		return plainText(v.Title), true
+	case PropertyRichText:
+		return plainText(v.RichText), true
+	case PropertySelect:
+		if v.Select == nil {
+			return "", true
+		}
+		return v.Select.Name, true
+	case PropertyURL:
+		return v.URL, true
+	case PropertyEmail:
+		return v.Email, true
+	case PropertyPhoneNumber:
+		return v.PhoneNumber, true
+	case PropertyCreatedBy:
+		if v.CreatedBy == nil {
+			return "", true
+		}
+		return v.CreatedBy.ID, true
+	case PropertyLastEditedBy:
+		if v.LastEditedBy == nil {
+			return "", true
+		}
+		return v.LastEditedBy.ID, true
+	case PropertyFormula:
+		if v.Formula != nil && v.Formula.Type == FormulaValueString {
+			return v.Formula.String, true
+		}
+	}
+	return "", false
+}
+
+func (v *PropertyValue) number() (float64, bool) {
+	switch v.Type {
+	case PropertyNumber:
+		return v.Number, true
+	case PropertyFormula:
+		if v.Formula != nil && v.Formula.Type == FormulaValueNumber {
+			return v.Formula.Number, true
+		}
+	case PropertyRollup:
+		if v.Rollup != nil && v.Rollup.Type == RollupValueNumber {
+			return v.Rollup.Number, true
+		}
+	}
+	return 0, false
+}
+
+func (v *PropertyValue) bool() (bool, bool) {
+	switch v.Type {
+	case PropertyCheckbox:
+		return v.Checkbox, true
+	case PropertyFormula:
+		if v.Formula != nil && v.Formula.Type == FormulaValueBoolen {
+			return v.Formula.Boolean, true
+		}
+	}
+	return false, false
+}
+
+func (v *PropertyValue) date() (*Date, bool) {
+	switch v.Type {
+	case PropertyDate:
+		return v.Date, true
+	case PropertyCreatedTime:
+		if v.CreatedTime == nil {
+			return nil, true
+		}
+		return &Date{Start: NewDateTime(*v.CreatedTime)}, true
+	case PropertyLastEditedTime:
+		if v.LastEditedTime == nil {
+			return nil, true
+		}
+		return &Date{Start: NewDateTime(*v.LastEditedTime)}, true
+	case PropertyFormula:
+		if v.Formula != nil && v.Formula.Type == FormulaValueDate {
+			return v.Formula.Date, true
+		}
+	case PropertyRollup:
+		if v.Rollup != nil && v.Rollup.Type == RolluoValueDate {
+			return v.Rollup.Date, true
+		}
+	}
+	return nil, false
+}
+
+func (v *PropertyValue) strings() ([]string, bool) {
+	var strs []string
+	switch v.Type {
+	case PropertyMultiSelect:
+		for _, o := range v.MultiSelect {
+			strs = append(strs, o.Name)
+		}
+	case PropertySelect:
+		if v.Select != nil {
+			strs = append(strs, v.Select.Name)
+		}
+	case PropertyRelation:
+		for _, r := range v.Relation {
+			strs = append(strs, r.ID)
+		}
+	case PropertyPeople:
+		for _, u := range v.People {
+			strs = append(strs, u.ID)
+		}
+	case PropertyFile:
+		for _, f := range v.Files {
+			strs = append(strs, f.URL())
+		}
+	default:
+		return nil, false
+	}
+	return strs, true
+}
+
+func plainText(texts []*RichText) string {
+	var b strings.Builder
+	for _, t := range texts {
+		if t == nil {
+			continue
+		}
+		if t.PlainText == "" && t.Text != nil {
+			b.WriteString(t.Text.Content)
+		} else {
+			b.WriteString(t.PlainText)
+		}
+	}
+	return b.String()
+}
+
+// MarshalProperties encodes the struct v to property values by struct tags, see UnmarshalPage for the tags.
+// The result can be used as properties of CreatePageParam and UpdatePageParam.
+//
+// Fields of read-only properties, i.e. formulas, rollups, created and last edited time and users, are skipped.
+// Nil pointer fields are skipped, while empty strings, select and zero time.Time clear the properties.
+// Type in tag can be omitted for numbers, bools as checkbox, dates and []string as multi-select.
+// Strings may be titles, texts, selects, URLs and so on, so they require a type, e.g. `notion:"Name,title"`.
+func MarshalProperties(v interface{}) (map[string]*PropertyValue, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := codecFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]*PropertyValue, len(fields))
+	for _, f := range fields {
+		value, err := encodePropertyValue(f.propertyType, rv.FieldByIndex(f.index))
+		if err != nil {
+			return nil, fmt.Errorf("notion: encode property %q: %w", f.name, err)
+		}
+		if value != nil {
+			properties[f.name] = value
+		}
+	}
+	return properties, nil
+}
+
+// encodePropertyValue encodes src to property value of type, returns nil if it should be skipped.
+func encodePropertyValue(propertyType PropertyType, src reflect.Value) (*PropertyValue, error) {
+	switch propertyType {
+	case PropertyFormula, PropertyRollup, PropertyCreatedTime, PropertyCreatedBy, PropertyLastEditedTime, PropertyLastEditedBy:
+		return nil, nil
+	}
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			return nil, nil
+		}
+		src = src.Elem()
+	}
+
+	unsupported := fmt.Errorf("%s value can't be encoded from %s", propertyType, src.Type())
+	switch propertyType {
+	case PropertyTitle, PropertyRichText, PropertySelect, PropertyURL, PropertyEmail, PropertyPhoneNumber:
+		if src.Kind() != reflect.String {
+			return nil, unsupported
+		}
+		s := src.String()
+		switch propertyType {
+		case PropertyTitle:
+			return NewTitlePropertyValue(newPlainText(s)...), nil
+		case PropertyRichText:
+			return NewRichTextPropertyValue(newPlainText(s)...), nil
+		case PropertySelect:
+			if s == "" {
+				return NewClearPropertyValue(PropertySelect), nil
+			}
+			return NewSelectPropertyValue(NewSelectOption(s, "")), nil
+		case PropertyURL:
+			return NewURLPropertyValue(s), nil
+		case PropertyEmail:
+			return NewEmailPropertyValue(s), nil
+		default:
+			return NewPhoneNumberPropertyValue(s), nil
+		}
+	case PropertyNumber:
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return NewNumberPropertyValue(float64(src.Int())), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return NewNumberPropertyValue(float64(src.Uint())), nil
+		case reflect.Float32, reflect.Float64:
+			return NewNumberPropertyValue(src.Float()), nil
+		}
+	case PropertyCheckbox:
+		if src.Kind() == reflect.Bool {
+			return NewCheckboxPropertyValue(src.Bool()), nil
+		}
+	case PropertyDate:
+		switch src.Type() {
+		case timeType:
+			t := src.Interface().(time.Time)
+			if t.IsZero() {
+				return NewClearPropertyValue(PropertyDate), nil
+			}
+			return NewDatePropertyValue(&Date{Start: NewDateTime(t)}), nil
+		case dateTimeType:
+			t := src.Interface().(DateTime)
+			if t.IsZero() {
+				return NewClearPropertyValue(PropertyDate), nil
+			}
+			return NewDatePropertyValue(&Date{Start: t}), nil
+		case dateType:
+			date := src.Interface().(Date)
+			return NewDatePropertyValue(&date), nil
+		}
+	case PropertyMultiSelect, PropertyRelation, PropertyPeople, PropertyFile:
+		if src.Kind() != reflect.Slice || src.Type().Elem().Kind() != reflect.String {
+			return nil, unsupported
+		}
+		value := &PropertyValue{Type: propertyType}
+		for i := 0; i < src.Len(); i++ {
+			s := src.Index(i).String()
+			switch propertyType {
+			case PropertyMultiSelect:
+				value.MultiSelect = append(value.MultiSelect, NewSelectOption(s, ""))
+			case PropertyRelation:
+				value.Relation = append(value.Relation, &ObjectReference{ID: s})
+			case PropertyPeople:
+				value.People = append(value.People, &User{Object: ObjectUser, ID: s})
+			default:
+				value.Files = append(value.Files, NewExternalFile(s, s))
+			}
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unknown property type %q", propertyType)
+	}
+	return nil, unsupported
+}
+
+// newPlainText creates rich texts of content without annotations, returns empty for empty content.
+func newPlainText(content string) []*RichText {
+	if content == "" {
+		return []*RichText{}
+	}
+	return []*RichText{{Type: RichTextText, PlainText: content, Text: &Text{Content: content}}}
+}
@@ -0,0 +1,178 @@
+package notion
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTaskStatus string
+
+type testTaskBase struct {
+	Name string `notion:"Name,title"`
+}
+
+type testTask struct {
+	testTaskBase
+	Status    testTaskStatus `notion:"Status,select"`
+	Tags      []string       `notion:"Tags,multi_select"`
+	Estimate  *float64       `notion:"Estimate,number"`
+	Points    int            `notion:"Points"`
+	Done      bool           `notion:"Done,checkbox"`
+	Due       *time.Time     `notion:"Due,date"`
+	Period    *Date          `notion:"Period,date"`
+	Owners    []string       `notion:"Owners,people"`
+	Blocks    []string       `notion:"Blocks,relation"`
+	Link      *string        `notion:"Link,url"`
+	Score     float64        `notion:"Score,formula"`
+	CreatedAt time.Time      `notion:"Created,created_time"`
+	Ignored   string         `notion:"-"`
+	Untagged  string
+}
+
+const testTaskPage = `{
+	"object": "page",
+	"id": "page",
+	"properties": {
+		"Name": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Write docs", "text": {"content": "Write docs"}}]},
+		"Status": {"id": "a", "type": "select", "select": {"name": "Done"}},
+		"Tags": {"id": "b", "type": "multi_select", "multi_select": [{"name": "docs"}, {"name": "api"}]},
+		"Estimate": {"id": "c", "type": "number", "number": null},
+		"Points": {"id": "d", "type": "number", "number": 3},
+		"Done": {"id": "e", "type": "checkbox", "checkbox": true},
+		"Due": {"id": "f", "type": "date", "date": {"start": "2021-06-01T10:00:00Z"}},
+		"Period": {"id": "g", "type": "date", "date": {"start": "2021-06-01", "end": "2021-06-03"}},
+		"Owners": {"id": "h", "type": "people", "people": [{"object": "user", "id": "user"}]},
+		"Blocks": {"id": "i", "type": "relation", "relation": [{"id": "other"}]},
+		"Link": {"id": "j", "type": "url", "url": null},
+		"Score": {"id": "k", "type": "formula", "formula": {"type": "number", "number": 4.5}},
+		"Created": {"id": "l", "type": "created_time", "created_time": "2021-05-01T00:00:00Z"}
+	}
+}`
+
+func TestUnmarshalPage(t *testing.T) {
+	var page Page
+	require.NoError(t, json.Unmarshal([]byte(testTaskPage), &page))
+
+	task := testTask{Ignored: "kept", Link: new(string)}
+	require.NoError(t, UnmarshalPage(&page, &task))
+	assert.Equal(t, "Write docs", task.Name)
+	assert.Equal(t, testTaskStatus("Done"), task.Status)
+	assert.Equal(t, []string{"docs", "api"}, task.Tags)
+	assert.Nil(t, task.Estimate)
+	assert.Equal(t, 3, task.Points)
+	assert.True(t, task.Done)
+	require.NotNil(t, task.Due)
+	assert.Equal(t, time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC), *task.Due)
+	require.NotNil(t, task.Period)
+	assert.True(t, task.Period.Start.DateOnly)
+	require.NotNil(t, task.Period.End)
+	assert.Equal(t, 3, task.Period.End.Day())
+	assert.Equal(t, []string{"user"}, task.Owners)
+	assert.Equal(t, []string{"other"}, task.Blocks)
+	assert.Nil(t, task.Link)
+	assert.Equal(t, 4.5, task.Score)
+	assert.Equal(t, time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), task.CreatedAt)
+	assert.Equal(t, "kept", task.Ignored)
+
+	t.Run("type mismatch", func(t *testing.T) {
+		var v struct {
+			Status string `notion:"Status,rich_text"`
+		}
+		assert.EqualError(t, UnmarshalPage(&page, &v), `notion: property "Status" is select, not rich_text`)
+	})
+
+	t.Run("unsupported field", func(t *testing.T) {
+		var v struct {
+			Tags bool `notion:"Tags,multi_select"`
+		}
+		assert.EqualError(t, UnmarshalPage(&page, &v), `notion: decode property "Tags": multi_select value can't be decoded into bool`)
+	})
+
+	t.Run("non-integral number", func(t *testing.T) {
+		var v struct {
+			Score int `notion:"Score"`
+		}
+		assert.EqualError(t, UnmarshalPage(&page, &v), `notion: decode property "Score": 4.5 is not an integer of int`)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		var v struct {
+			Due time.Time `notion:"Due,dat"`
+		}
+		assert.Error(t, UnmarshalPage(&page, &v))
+	})
+
+	t.Run("name with comma", func(t *testing.T) {
+		var v struct {
+			Status string `notion:"Status, Done"`
+		}
+		assert.Error(t, UnmarshalPage(&page, &v), "names containing commas require a type")
+
+		var typed struct {
+			Status string `notion:"Status, Done,select"`
+		}
+		require.NoError(t, UnmarshalPage(&page, &typed))
+		assert.Empty(t, typed.Status, "absent property is left untouched")
+	})
+
+	t.Run("not pointer", func(t *testing.T) {
+		assert.Error(t, UnmarshalPage(&page, task))
+	})
+}
+
+func TestMarshalProperties(t *testing.T) {
+	estimate := 2.5
+	due := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	task := testTask{
+		testTaskBase: testTaskBase{Name: "Write docs"},
+		Status:       "",
+		Tags:         []string{"docs"},
+		Estimate:     &estimate,
+		Done:         false,
+		Due:          &due,
+		Owners:       []string{"user"},
+		Blocks:       []string{},
+		Score:        1,
+	}
+	properties, err := MarshalProperties(task)
+	require.NoError(t, err)
+	b, err := json.Marshal(properties)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Name": {"type": "title", "title": [{"type": "text", "plain_text": "Write docs", "text": {"content": "Write docs"}, "annotations": {}}]},
+		"Status": {"type": "select", "select": null},
+		"Tags": {"type": "multi_select", "multi_select": [{"name": "docs"}]},
+		"Estimate": {"type": "number", "number": 2.5},
+		"Points": {"type": "number", "number": 0},
+		"Done": {"type": "checkbox", "checkbox": false},
+		"Due": {"type": "date", "date": {"start": "2021-06-01T10:00:00Z", "end": null}},
+		"Owners": {"type": "people", "people": [{"object": "user", "id": "user"}]},
+		"Blocks": {"type": "relation", "relation": []}
+	}`, string(b))
+
+	t.Run("unsupported field", func(t *testing.T) {
+		_, err := MarshalProperties(struct {
+			Done string `notion:"Done,checkbox"`
+		}{})
+		assert.EqualError(t, err, `notion: encode property "Done": checkbox value can't be encoded from string`)
+	})
+
+	t.Run("ambiguous type", func(t *testing.T) {
+		_, err := MarshalProperties(struct {
+			Data map[string]string `notion:"Data"`
+		}{})
+		assert.Error(t, err)
+	})
+
+	t.Run("string without type", func(t *testing.T) {
+		_, err := MarshalProperties(struct {
+			Name string `notion:"Name"`
+		}{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), ".Name of string needs property type")
+	})
+}
@@ -0,0 +1,37 @@
+package notion
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Comment object represents a comment on a page or block.
+// Comments in the same discussion thread share the same DiscussionID.
+type Comment struct {
+	Object ObjectType `json:"object,omitempty"`
+	ID     string     `json:"id,omitempty"`
+	// Parent is either a page parent or a block parent.
+	Parent         Parent      `json:"parent,omitempty"`
+	DiscussionID   string      `json:"discussion_id,omitempty"`
+	CreatedTime    time.Time   `json:"created_time"`
+	LastEditedTime time.Time   `json:"last_edited_time"`
+	CreatedBy      *User       `json:"created_by,omitempty"`
+	RichText       []*RichText `json:"rich_text,omitempty"`
+}
+
+// MarshalJSON marshal Comment to json and set Object to "comment" automatically.
+func (c *Comment) MarshalJSON() ([]byte, error) {
+	if c == nil {
+		return json.Marshal(nil)
+	}
+	c.Object = ObjectComment
+	type Alias Comment
+	return json.Marshal((*Alias)(c))
+}
+
+var _ json.Marshaler = &Comment{}
+
+// NewBlockParent creates a block parent, which is only available for comments.
+func NewBlockParent(blockID string) Parent {
+	return Parent{Type: ParentBlock, BlockID: blockID}
+}
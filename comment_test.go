@@ -0,0 +1,56 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateComment(t *testing.T) {
+	var body string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/comments", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		_, _ = w.Write([]byte(`{"object":"comment","id":"comment","discussion_id":"discussion","parent":{"type":"page_id","page_id":"page"}}`))
+	}, Settings{})
+	ctx := context.Background()
+	text := []*RichText{{Type: RichTextText, Text: &Text{Content: "failed validation"}}}
+
+	parent := NewPageParent("page")
+	comment, err := client.CreateComment(ctx, CreateCommentParam{Parent: &parent, RichText: text})
+	require.NoError(t, err)
+	assert.Equal(t, "discussion", comment.DiscussionID)
+	assert.Equal(t, ParentPage, comment.Parent.Type)
+	assert.Equal(t, ParentPage, parent.Type)
+	assert.JSONEq(t, `{"parent":{"page_id":"page"},"rich_text":[{"type":"text","text":{"content":"failed validation"},"annotations":{}}]}`, body)
+
+	_, err = client.CreateComment(ctx, CreateCommentParam{DiscussionID: "discussion", RichText: text})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"discussion_id":"discussion","rich_text":[{"type":"text","text":{"content":"failed validation"},"annotations":{}}]}`, body)
+
+	_, err = client.CreateComment(ctx, CreateCommentParam{RichText: text})
+	assert.Error(t, err)
+}
+
+func TestClient_ListComments(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "block", r.URL.Query().Get("block_id"))
+		pagedHandler(t, ObjectComment, 3, 2)(w, r)
+	}, Settings{})
+
+	comments, err := NewListCommentsIterator(client, "block", 2).CollectAll(context.Background(), 0)
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+	assert.Equal(t, "2", comments[2].ID)
+
+	var o Object
+	require.NoError(t, json.Unmarshal([]byte(`{"object":"comment","id":"comment"}`), &o))
+	assert.Equal(t, "comment", o.Comment().ID)
+}
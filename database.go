@@ -1,6 +1,11 @@
 package notion
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Database objects describe the property schema of a database in Notion.
 // Page are the items (or children) in a database.
@@ -12,6 +17,13 @@ type Database struct {
 	LastEditedTime time.Time           `json:"last_edited_time,omitempty"`
 	Title          []*RichText         `json:"title,omitempty"`
 	Properties     map[string]Property `json:"properties,omitempty"`
+	Icon           *Icon               `json:"icon,omitempty"`
+	Cover          *Cover              `json:"cover,omitempty"`
+	// URL is the link to the database in Notion.
+	URL string `json:"url,omitempty"`
+
+	// formulaTypes are result types of formulas by property ID, which are seen in query results rather than the schema.
+	formulaTypes map[string]FormulaValueType
 }
 
 // PropertyType is type of database Property.
@@ -26,7 +38,7 @@ const (
 	PropertyMultiSelect    PropertyType = "multi_select"
 	PropertyDate           PropertyType = "date"
 	PropertyPeople         PropertyType = "people"
-	PropertyFile           PropertyType = "file"
+	PropertyFile           PropertyType = "files"
 	PropertyCheckbox       PropertyType = "checkbox"
 	PropertyURL            PropertyType = "url"
 	PropertyEmail          PropertyType = "email"
@@ -58,47 +70,83 @@ const (
 	NumberFormatYuan             NumberFormat = "yuan"
 )
 
+// RollupFunction is the function used to calculate rollup value.
+type RollupFunction string
+
+// RollupFunction enums.
+const (
+	RollupCountAll          RollupFunction = "count_all"
+	RollupCountValues       RollupFunction = "count_values"
+	RollupCountUniqueValues RollupFunction = "count_unique_values"
+	RollupCountEmpty        RollupFunction = "count_empty"
+	RollupCountNotEmpty     RollupFunction = "count_not_empty"
+	RollupPercentEmpty      RollupFunction = "percent_empty"
+	RollupPercentNotEmpty   RollupFunction = "percent_not_empty"
+	RollupSum               RollupFunction = "sum"
+	RollupAverage           RollupFunction = "average"
+	RollupMedian            RollupFunction = "median"
+	RollupMin               RollupFunction = "min"
+	RollupMax               RollupFunction = "max"
+	RollupRange             RollupFunction = "range"
+	RollupShowOriginal      RollupFunction = "show_original"
+)
+
 // Property is mix type of database property.
 type Property struct {
-	ID       string       `json:"id,omitempty"`
-	Type     PropertyType `json:"type,omitempty"`
-	Title    *struct{}    `json:"title,omitempty"`
-	RichText *struct{}    `json:"rich_text,omitempty"`
-	Number   *struct {
-		Format NumberFormat `json:"format,omitempty"`
-	} `json:"number,omitempty"`
-	Select *struct {
-		Options []*SelectOption `json:"options,omitempty"`
-	} `json:"select,omitempty"`
-	MultiSelect *struct {
-		Options []*SelectOption `json:"options,omitempty"`
-	} `json:"multi_select,omitempty"`
-	Checkbox    *struct{} `json:"checkbox,omitempty"`
-	URL         *struct{} `json:"url,omitempty"`
-	Email       *struct{} `json:"email,omitempty"`
-	PhoneNumber *struct{} `json:"phone_number,omitempty"`
-	Formula     *struct {
-		Expression string `json:"expression,omitempty"`
-	} `json:"formula,omitempty"`
-	Relation *struct {
-		DatabaseID         string `json:"database_id,omitempty"`
-		SyncedPropertyName string `json:"synced_property_name,omitempty"`
-		SyncedPropertyID   string `json:"synced_property_id,omitempty"`
-	} `json:"relation,omitempty"`
-	Rollup *struct {
-		RelationPropertyName string `json:"relation_property_name,omitempty"`
-		RelationPropertyID   string `json:"relation_property_id,omitempty"`
-		RollupPropertyName   string `json:"rollup_property_name,omitempty"`
-		RollupPropertyID     string `json:"rollup_property_id,omitempty"`
-		Function             string `json:"function,omitempty"`
-	} `json:"rollup,omitempty"`
-	People         *struct{} `json:"people,omitempty"`
-	Date           *struct{} `json:"date,omitempty"`
-	File           *struct{} `json:"files,omitempty"`
-	CreatedTime    *struct{} `json:"created_time,omitempty"`
-	CreatedBy      *struct{} `json:"created_by,omitempty"`
-	LastEditedTime *struct{} `json:"last_edited_time,omitempty"`
-	LastEditedBy   *struct{} `json:"last_edited_by,omitempty"`
+	ID string `json:"id,omitempty"`
+	// Name is used to rename the property when updating database.
+	Name           string                  `json:"name,omitempty"`
+	Type           PropertyType            `json:"type,omitempty"`
+	Title          *struct{}               `json:"title,omitempty"`
+	RichText       *struct{}               `json:"rich_text,omitempty"`
+	Number         *NumberPropertyConfig   `json:"number,omitempty"`
+	Select         *SelectPropertyConfig   `json:"select,omitempty"`
+	MultiSelect    *SelectPropertyConfig   `json:"multi_select,omitempty"`
+	Checkbox       *struct{}               `json:"checkbox,omitempty"`
+	URL            *struct{}               `json:"url,omitempty"`
+	Email          *struct{}               `json:"email,omitempty"`
+	PhoneNumber    *struct{}               `json:"phone_number,omitempty"`
+	Formula        *FormulaPropertyConfig  `json:"formula,omitempty"`
+	Relation       *RelationPropertyConfig `json:"relation,omitempty"`
+	Rollup         *RollupPropertyConfig   `json:"rollup,omitempty"`
+	People         *struct{}               `json:"people,omitempty"`
+	Date           *struct{}               `json:"date,omitempty"`
+	File           *struct{}               `json:"files,omitempty"`
+	CreatedTime    *struct{}               `json:"created_time,omitempty"`
+	CreatedBy      *struct{}               `json:"created_by,omitempty"`
+	LastEditedTime *struct{}               `json:"last_edited_time,omitempty"`
+	LastEditedBy   *struct{}               `json:"last_edited_by,omitempty"`
+}
+
+// NumberPropertyConfig is the configuration of number property.
+type NumberPropertyConfig struct {
+	Format NumberFormat `json:"format,omitempty"`
+}
+
+// SelectPropertyConfig is the configuration of select and multi select property.
+type SelectPropertyConfig struct {
+	Options []*SelectOption `json:"options,omitempty"`
+}
+
+// FormulaPropertyConfig is the configuration of formula property.
+type FormulaPropertyConfig struct {
+	Expression string `json:"expression,omitempty"`
+}
+
+// RelationPropertyConfig is the configuration of relation property.
+type RelationPropertyConfig struct {
+	DatabaseID         string `json:"database_id,omitempty"`
+	SyncedPropertyName string `json:"synced_property_name,omitempty"`
+	SyncedPropertyID   string `json:"synced_property_id,omitempty"`
+}
+
+// RollupPropertyConfig is the configuration of rollup property.
+type RollupPropertyConfig struct {
+	RelationPropertyName string         `json:"relation_property_name,omitempty"`
+	RelationPropertyID   string         `json:"relation_property_id,omitempty"`
+	RollupPropertyName   string         `json:"rollup_property_name,omitempty"`
+	RollupPropertyID     string         `json:"rollup_property_id,omitempty"`
+	Function             RollupFunction `json:"function,omitempty"`
 }
 
 // SelectOption is the option value of single selector and multi selector.
@@ -107,3 +155,260 @@ type SelectOption struct {
 	ID    string `json:"id,omitempty"`
 	Color Color  `json:"color,omitempty"`
 }
+
+// NewSelectOption creates a SelectOption.
+func NewSelectOption(name string, color Color) *SelectOption {
+	return &SelectOption{Name: name, Color: color}
+}
+
+// NewRenameProperty creates a Property that only renames the property when updating database.
+func NewRenameProperty(name string) *Property {
+	return &Property{Name: name}
+}
+
+// NewTitleProperty creates a title Property.
+func NewTitleProperty() Property {
+	return Property{Type: PropertyTitle, Title: &struct{}{}}
+}
+
+// NewRichTextProperty creates a rich text Property.
+func NewRichTextProperty() Property {
+	return Property{Type: PropertyRichText, RichText: &struct{}{}}
+}
+
+// NewNumberProperty creates a number Property.
+func NewNumberProperty(format NumberFormat) Property {
+	return Property{Type: PropertyNumber, Number: &NumberPropertyConfig{Format: format}}
+}
+
+// NewSelectProperty creates a select Property with options.
+func NewSelectProperty(options ...*SelectOption) Property {
+	return Property{Type: PropertySelect, Select: &SelectPropertyConfig{Options: options}}
+}
+
+// NewMultiSelectProperty creates a multi select Property with options.
+func NewMultiSelectProperty(options ...*SelectOption) Property {
+	return Property{Type: PropertyMultiSelect, MultiSelect: &SelectPropertyConfig{Options: options}}
+}
+
+// NewDateProperty creates a date Property.
+func NewDateProperty() Property {
+	return Property{Type: PropertyDate, Date: &struct{}{}}
+}
+
+// NewPeopleProperty creates a people Property.
+func NewPeopleProperty() Property {
+	return Property{Type: PropertyPeople, People: &struct{}{}}
+}
+
+// NewFilesProperty creates a files Property.
+func NewFilesProperty() Property {
+	return Property{Type: PropertyFile, File: &struct{}{}}
+}
+
+// NewCheckboxProperty creates a checkbox Property.
+func NewCheckboxProperty() Property {
+	return Property{Type: PropertyCheckbox, Checkbox: &struct{}{}}
+}
+
+// NewURLProperty creates a url Property.
+func NewURLProperty() Property {
+	return Property{Type: PropertyURL, URL: &struct{}{}}
+}
+
+// NewEmailProperty creates an email Property.
+func NewEmailProperty() Property {
+	return Property{Type: PropertyEmail, Email: &struct{}{}}
+}
+
+// NewPhoneNumberProperty creates a phone number Property.
+func NewPhoneNumberProperty() Property {
+	return Property{Type: PropertyPhoneNumber, PhoneNumber: &struct{}{}}
+}
+
+// NewFormulaProperty creates a formula Property with expression.
+func NewFormulaProperty(expression string) Property {
+	return Property{Type: PropertyFormula, Formula: &FormulaPropertyConfig{Expression: expression}}
+}
+
+// NewRelationProperty creates a relation Property targeting the database.
+func NewRelationProperty(databaseID string) Property {
+	return Property{Type: PropertyRelation, Relation: &RelationPropertyConfig{DatabaseID: databaseID}}
+}
+
+// NewRollupProperty creates a rollup Property,
+// which rolls up rollupProperty of the pages related by relationProperty with function.
+// The properties are specified by names.
+func NewRollupProperty(relationProperty, rollupProperty string, function RollupFunction) Property {
+	return Property{Type: PropertyRollup, Rollup: &RollupPropertyConfig{
+		RelationPropertyName: relationProperty,
+		RollupPropertyName:   rollupProperty,
+		Function:             function,
+	}}
+}
+
+// NewCreatedTimeProperty creates a created time Property.
+func NewCreatedTimeProperty() Property {
+	return Property{Type: PropertyCreatedTime, CreatedTime: &struct{}{}}
+}
+
+// NewCreatedByProperty creates a created by Property.
+func NewCreatedByProperty() Property {
+	return Property{Type: PropertyCreatedBy, CreatedBy: &struct{}{}}
+}
+
+// NewLastEditedTimeProperty creates a last edited time Property.
+func NewLastEditedTimeProperty() Property {
+	return Property{Type: PropertyLastEditedTime, LastEditedTime: &struct{}{}}
+}
+
+// NewLastEditedByProperty creates a last edited by Property.
+func NewLastEditedByProperty() Property {
+	return Property{Type: PropertyLastEditedBy, LastEditedBy: &struct{}{}}
+}
+
+// propertyConfig describes whether the config of a property type is set.
+type propertyConfig struct {
+	typ PropertyType
+	set bool
+}
+
+// configs returns propertyConfig of all types, in the order of declaration.
+func (p *Property) configs() []propertyConfig {
+	return []propertyConfig{
+		{PropertyTitle, p.Title != nil},
+		{PropertyRichText, p.RichText != nil},
+		{PropertyNumber, p.Number != nil},
+		{PropertySelect, p.Select != nil},
+		{PropertyMultiSelect, p.MultiSelect != nil},
+		{PropertyCheckbox, p.Checkbox != nil},
+		{PropertyURL, p.URL != nil},
+		{PropertyEmail, p.Email != nil},
+		{PropertyPhoneNumber, p.PhoneNumber != nil},
+		{PropertyFormula, p.Formula != nil},
+		{PropertyRelation, p.Relation != nil},
+		{PropertyRollup, p.Rollup != nil},
+		{PropertyPeople, p.People != nil},
+		{PropertyDate, p.Date != nil},
+		{PropertyFile, p.File != nil},
+		{PropertyCreatedTime, p.CreatedTime != nil},
+		{PropertyCreatedBy, p.CreatedBy != nil},
+		{PropertyLastEditedTime, p.LastEditedTime != nil},
+		{PropertyLastEditedBy, p.LastEditedBy != nil},
+	}
+}
+
+// Validate checks whether the Property is a valid configuration of its Type,
+// the config of Type must be set and configs of other types must not be set.
+func (p Property) Validate() error {
+	var known bool
+	for _, c := range p.configs() {
+		if c.typ == p.Type {
+			known = true
+			if !c.set {
+				return fmt.Errorf("%s property requires %s config", p.Type, p.Type)
+			}
+		} else if c.set {
+			return fmt.Errorf("%s property cannot contain %s config", p.Type, c.typ)
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown property type %q", p.Type)
+	}
+
+	switch p.Type {
+	case PropertyNumber:
+		if f := p.Number.Format; f != "" && !validNumberFormats[f] {
+			return fmt.Errorf("unknown number format %q", f)
+		}
+	case PropertySelect:
+		return validateSelectOptions(p.Select.Options)
+	case PropertyMultiSelect:
+		return validateSelectOptions(p.MultiSelect.Options)
+	case PropertyFormula:
+		if p.Formula.Expression == "" {
+			return errors.New("formula property requires expression")
+		}
+	case PropertyRelation:
+		if p.Relation.DatabaseID == "" {
+			return errors.New("relation property requires database id")
+		}
+	case PropertyRollup:
+		r := p.Rollup
+		if r.RelationPropertyName == "" && r.RelationPropertyID == "" {
+			return errors.New("rollup property requires relation property name or id")
+		}
+		if r.RollupPropertyName == "" && r.RollupPropertyID == "" {
+			return errors.New("rollup property requires rollup property name or id")
+		}
+		if !validRollupFunctions[r.Function] {
+			return fmt.Errorf("unknown rollup function %q", r.Function)
+		}
+	}
+	return nil
+}
+
+func validateSelectOptions(options []*SelectOption) error {
+	names := make(map[string]bool, len(options))
+	for _, o := range options {
+		if o == nil || o.Name == "" {
+			return errors.New("select option requires name")
+		}
+		if strings.Contains(o.Name, ",") {
+			return fmt.Errorf("select option %q cannot contain comma", o.Name)
+		}
+		if names[o.Name] {
+			return fmt.Errorf("duplicated select option %q", o.Name)
+		}
+		names[o.Name] = true
+		if o.Color != "" && !validSelectColors[o.Color] {
+			return fmt.Errorf("invalid color %q of select option %q", o.Color, o.Name)
+		}
+	}
+	return nil
+}
+
+var validNumberFormats = map[NumberFormat]bool{
+	NumberFormatNumber:           true,
+	NumberFormatNumberWithCommas: true,
+	NumberFormatPercent:          true,
+	NumberFormatDollar:           true,
+	NumberFormatEuro:             true,
+	NumberFormatPound:            true,
+	NumberFormatYen:              true,
+	NumberFormatRuble:            true,
+	NumberFormatRupee:            true,
+	NumberFormatWon:              true,
+	NumberFormatYuan:             true,
+}
+
+var validRollupFunctions = map[RollupFunction]bool{
+	RollupCountAll:          true,
+	RollupCountValues:       true,
+	RollupCountUniqueValues: true,
+	RollupCountEmpty:        true,
+	RollupCountNotEmpty:     true,
+	RollupPercentEmpty:      true,
+	RollupPercentNotEmpty:   true,
+	RollupSum:               true,
+	RollupAverage:           true,
+	RollupMedian:            true,
+	RollupMin:               true,
+	RollupMax:               true,
+	RollupRange:             true,
+	RollupShowOriginal:      true,
+}
+
+// validSelectColors are colors available for select options, background colors are not allowed.
+var validSelectColors = map[Color]bool{
+	ColorDefault: true,
+	ColorGray:    true,
+	ColorBrown:   true,
+	ColorOrange:  true,
+	ColorYellow:  true,
+	ColorGreen:   true,
+	ColorBlue:    true,
+	ColorPurple:  true,
+	ColorPink:    true,
+	ColorRed:     true,
+}
@@ -0,0 +1,126 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProperty_Validate(t *testing.T) {
+	valid := []Property{
+		NewTitleProperty(),
+		NewRichTextProperty(),
+		NewNumberProperty(NumberFormatDollar),
+		NewNumberProperty(""),
+		NewSelectProperty(NewSelectOption("a", ColorRed), NewSelectOption("b", "")),
+		NewMultiSelectProperty(),
+		NewDateProperty(),
+		NewPeopleProperty(),
+		NewFilesProperty(),
+		NewCheckboxProperty(),
+		NewURLProperty(),
+		NewEmailProperty(),
+		NewPhoneNumberProperty(),
+		NewFormulaProperty(`prop("Price") * 2`),
+		NewRelationProperty("database"),
+		NewRollupProperty("Tasks", "Price", RollupSum),
+		NewCreatedTimeProperty(),
+		NewCreatedByProperty(),
+		NewLastEditedTimeProperty(),
+		NewLastEditedByProperty(),
+	}
+	for _, p := range valid {
+		assert.NoError(t, p.Validate(), p.Type)
+	}
+
+	invalid := map[string]Property{
+		"unknown type":      {Type: "unknown"},
+		"missing config":    {Type: PropertyNumber},
+		"mixed config":      {Type: PropertyTitle, Title: &struct{}{}, Date: &struct{}{}},
+		"number format":     NewNumberProperty("bitcoin"),
+		"option name":       NewSelectProperty(NewSelectOption("", ColorRed)),
+		"option comma":      NewSelectProperty(NewSelectOption("a,b", ColorRed)),
+		"option duplicated": NewMultiSelectProperty(NewSelectOption("a", ColorRed), NewSelectOption("a", ColorBlue)),
+		"option color":      NewSelectProperty(NewSelectOption("a", ColorRedBackground)),
+		"formula":           NewFormulaProperty(""),
+		"relation":          NewRelationProperty(""),
+		"rollup relation":   NewRollupProperty("", "Price", RollupSum),
+		"rollup property":   NewRollupProperty("Tasks", "", RollupSum),
+		"rollup function":   NewRollupProperty("Tasks", "Price", "product"),
+	}
+	for name, p := range invalid {
+		assert.Error(t, p.Validate(), name)
+	}
+}
+
+func TestClient_CreateDatabase(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/databases", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"object":"database","id":"database"}`))
+	}, Settings{})
+	ctx := context.Background()
+
+	database, err := client.CreateDatabase(ctx, NewPageParent("page"), nil, map[string]Property{
+		"Name":   NewTitleProperty(),
+		"Status": NewSelectProperty(NewSelectOption("Done", ColorGreen)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "database", database.ID)
+	assert.Equal(t, map[string]interface{}{"page_id": "page"}, body["parent"])
+	assert.Equal(t, map[string]interface{}{
+		"Name": map[string]interface{}{"type": "title", "title": map[string]interface{}{}},
+		"Status": map[string]interface{}{"type": "select", "select": map[string]interface{}{
+			"options": []interface{}{map[string]interface{}{"name": "Done", "color": "green"}},
+		}},
+	}, body["properties"])
+
+	_, err = client.CreateDatabase(ctx, NewPageParent("page"), nil, map[string]Property{
+		"Status": NewSelectProperty(),
+	})
+	assert.Error(t, err)
+	_, err = client.CreateDatabase(ctx, NewPageParent("page"), nil, map[string]Property{
+		"Name": NewTitleProperty(),
+		"Link": NewRelationProperty(""),
+	})
+	assert.Error(t, err)
+}
+
+func TestClient_UpdateDatabase(t *testing.T) {
+	var body string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/v1/databases/database", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		_, _ = w.Write([]byte(`{"object":"database","id":"database"}`))
+	}, Settings{})
+	ctx := context.Background()
+
+	status := NewSelectProperty(&SelectOption{ID: "1", Name: "Finished"}, NewSelectOption("Blocked", ColorRed))
+	status.Name = "State"
+	database, err := client.UpdateDatabase(ctx, "database", nil, map[string]*Property{
+		"Tags":   nil,
+		"abcd":   NewRenameProperty("Summary"),
+		"Status": &status,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "database", database.ID)
+	assert.JSONEq(t, `{"properties":{
+		"Tags":null,
+		"abcd":{"name":"Summary"},
+		"Status":{"name":"State","type":"select","select":{"options":[{"id":"1","name":"Finished"},{"name":"Blocked","color":"red"}]}}
+	}}`, body)
+
+	_, err = client.UpdateDatabase(ctx, "database", nil, map[string]*Property{
+		"Price": {Type: PropertyNumber},
+	})
+	assert.Error(t, err)
+}
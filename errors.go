@@ -2,6 +2,7 @@ package notion
 
 import (
 	"errors"
+	"time"
 )
 
 // ErrCode is the "code" field in Notion error
@@ -42,6 +43,8 @@ type Error struct {
 	Status  int     `json:"status,omitempty"`
 	Code    ErrCode `json:"code,omitempty"`
 	Message string  `json:"message,omitempty"`
+	// RetryAfter is parsed from the "Retry-After" header of rate limited responses.
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *Error) Error() string { return e.Message }
@@ -0,0 +1,647 @@
+package notion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Evaluator evaluates filters and sorts of database queries against pages in memory,
+// so cached or exported pages can be queried like QueryDatabase. The zero value is ready to use.
+//
+// Text conditions are case-insensitive, and dates are compared by day when either side is date-only.
+// Date ranges are compared by their start.
+// Properties missing from pages are matched as empty values.
+type Evaluator struct {
+	// Now returns the time relative date conditions like past_week are evaluated against. Defaults to time.Now.
+	Now func() time.Time
+	// Location is the time zone of days, e.g. "today". Defaults to time.Local.
+	Location *time.Location
+	// Database is the optional schema of pages. If set, queries are validated against it,
+	// and selects are sorted by the order of options like Notion does, rather than by name.
+	Database *Database
+}
+
+// FilterPages returns the pages matching filter with a zero Evaluator.
+func FilterPages(pages []*Page, filter *Filter) ([]*Page, error) {
+	var e Evaluator
+	return e.Filter(pages, filter)
+}
+
+// SortPages sorts pages in place by sorts with a zero Evaluator.
+func SortPages(pages []*Page, sorts []*Sort) error {
+	var e Evaluator
+	return e.Sort(pages, sorts)
+}
+
+// Query filters and sorts pages by param, the cursor and page size are ignored.
+// The result is a new slice, pages are not modified.
+func (e *Evaluator) Query(pages []*Page, param QueryDatabaseParam) ([]*Page, error) {
+	if err := ValidateQuery(e.Database, param); err != nil {
+		return nil, err
+	}
+	result, err := e.filter(pages, param.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.Sort(result, param.Sorts); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Filter returns the pages matching filter in their order, nil filter matches all pages.
+func (e *Evaluator) Filter(pages []*Page, filter *Filter) ([]*Page, error) {
+	if filter != nil {
+		if err := filter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return e.filter(pages, filter)
+}
+
+// filter is Filter of a validated filter.
+func (e *Evaluator) filter(pages []*Page, filter *Filter) ([]*Page, error) {
+	result := make([]*Page, 0, len(pages))
+	for _, page := range pages {
+		ok := true
+		if filter != nil {
+			var err error
+			if ok, err = e.match(page, filter, "filter"); err != nil {
+				return nil, err
+			}
+		}
+		if ok {
+			result = append(result, page)
+		}
+	}
+	return result, nil
+}
+
+// Match reports whether page matches filter, nil filter matches all pages.
+// The error is a *QueryError if the filter is invalid, or doesn't apply to the properties of page.
+func (e *Evaluator) Match(page *Page, filter *Filter) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+	if err := filter.Validate(); err != nil {
+		return false, err
+	}
+	return e.match(page, filter, "filter")
+}
+
+func (e *Evaluator) match(page *Page, f *Filter, path string) (bool, error) {
+	switch {
+	case f.And != nil:
+		for i, child := range f.And {
+			ok, err := e.match(page, child, fmt.Sprintf("%s.and[%d]", path, i))
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case f.Or != nil:
+		for i, child := range f.Or {
+			ok, err := e.match(page, child, fmt.Sprintf("%s.or[%d]", path, i))
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case f.CreatedTime != nil:
+		return e.matchDate(f.CreatedTime, &Date{Start: NewDateTime(page.CreatedTime)}), nil
+	case f.LastEditedTime != nil:
+		return e.matchDate(f.LastEditedTime, &Date{Start: NewDateTime(page.LastEditedTime)}), nil
+	}
+	condition := f.conditions()[0].name
+	v, ok := pageProperty(page, f.Property)
+	if !ok {
+		// Empty properties are left out of pages, so missing properties are matched as empty values.
+		if v, ok = e.emptyValue(f.Property, condition); !ok {
+			return false, &QueryError{Path: path, Reason: fmt.Sprintf("property %q does not exist", f.Property)}
+		}
+	}
+	if f.Formula != nil {
+		if v.Type != PropertyFormula {
+			return false, mismatchError(path, condition, v.Type, f.Property)
+		}
+		path += ".formula"
+		return e.matchFormula(f.Formula, v.Formula, path, f.Property)
+	}
+	matched, ok := e.matchValue(f, v)
+	if !ok {
+		return false, mismatchError(path, condition, v.Type, f.Property)
+	}
+	return matched, nil
+}
+
+func mismatchError(path, condition string, t PropertyType, property string) error {
+	return &QueryError{Path: path, Reason: fmt.Sprintf("%s condition doesn't apply to %s property %q", condition, t, property)}
+}
+
+// emptyValue returns the empty value of property missing in page, typed by Database if it's set, otherwise by condition.
+// ok is false if the property doesn't exist in Database.
+func (e *Evaluator) emptyValue(property, condition string) (v *PropertyValue, ok bool) {
+	t := filterPropertyTypes[condition][0]
+	if e.Database != nil {
+		p, ok := e.Database.property(property)
+		if !ok {
+			return nil, false
+		}
+		// The result types of empty rollups are unknown, so they follow the condition.
+		if p.Type != PropertyRollup {
+			t = p.Type
+		}
+	}
+	return &PropertyValue{Type: t, null: t == PropertyNumber}, true
+}
+
+// pageProperty finds the property of page by name or ID.
+func pageProperty(page *Page, nameOrID string) (*PropertyValue, bool) {
+	if v, ok := page.Properties[nameOrID]; ok {
+		return &v, true
+	}
+	for _, v := range page.Properties {
+		if v.ID != "" && v.ID == nameOrID {
+			return &v, true
+		}
+	}
+	return nil, false
+}
+
+// matchValue matches the property condition of leaf f, ok is false if it doesn't apply to the type of v.
+func (e *Evaluator) matchValue(f *Filter, v *PropertyValue) (matched bool, ok bool) {
+	if v.Type == PropertyFormula {
+		// Formulas only match formula conditions.
+		return false, false
+	}
+	switch {
+	case f.Text != nil:
+		s, ok := v.string()
+		if !ok || v.Type == PropertySelect || v.Type == PropertyCreatedBy || v.Type == PropertyLastEditedBy {
+			return false, false
+		}
+		return matchText(f.Text, s), true
+	case f.Number != nil:
+		n, ok := v.number()
+		if !ok {
+			return false, false
+		}
+		return matchNumber(f.Number, n, v.empty()), true
+	case f.Checkbox != nil:
+		b, ok := v.bool()
+		if !ok {
+			return false, false
+		}
+		return matchCheckbox(f.Checkbox, b), true
+	case f.Select != nil:
+		if v.Type != PropertySelect {
+			return false, false
+		}
+		c, name := f.Select, ""
+		if v.Select != nil {
+			name = v.Select.Name
+		}
+		switch {
+		case c.Equals != "":
+			return name == c.Equals, true
+		case c.DoesNotEqual != "":
+			return name != c.DoesNotEqual, true
+		case c.IsEmpty:
+			return name == "", true
+		}
+		return name != "", true
+	case f.MultiSelect != nil:
+		if v.Type != PropertyMultiSelect {
+			return false, false
+		}
+		names, _ := v.strings()
+		c := f.MultiSelect
+		return matchContains(c.Contains, c.DoesNotContain, c.IsEmpty, names, stringsEqual), true
+	case f.Date != nil:
+		d, ok := v.date()
+		if !ok {
+			return false, false
+		}
+		return e.matchDate(f.Date, d), true
+	case f.People != nil:
+		var ids []string
+		switch v.Type {
+		case PropertyPeople:
+			ids, _ = v.strings()
+		case PropertyCreatedBy, PropertyLastEditedBy:
+			if id, _ := v.string(); id != "" {
+				ids = append(ids, id)
+			}
+		default:
+			return false, false
+		}
+		c := f.People
+		return matchContains(c.Contains, c.DoesNotContain, c.IsEmpty, ids, sameID), true
+	case f.Files != nil:
+		if v.Type != PropertyFile {
+			return false, false
+		}
+		if f.Files.IsEmpty {
+			return len(v.Files) == 0, true
+		}
+		return len(v.Files) > 0, true
+	case f.Relation != nil:
+		if v.Type != PropertyRelation {
+			return false, false
+		}
+		ids, _ := v.strings()
+		c := f.Relation
+		return matchContains(c.Contains, c.DoesNotContain, c.IsEmpty, ids, sameID), true
+	}
+	return false, false
+}
+
+func (e *Evaluator) matchFormula(c *FormulaFilterCondition, v *FormulaValue, path, property string) (bool, error) {
+	if v == nil {
+		v = &FormulaValue{}
+	}
+	name := c.conditions()[0].name
+	if v.Type != "" && v.Type != formulaConditionTypes[name] {
+		return false, &QueryError{Path: path, Reason: fmt.Sprintf("%s condition doesn't apply to %s result of formula %q", name, v.Type, property)}
+	}
+	switch {
+	case c.Text != nil:
+		return matchText(c.Text, v.String), nil
+	case c.Number != nil:
+		return matchNumber(c.Number, v.Number, v.Type == ""), nil
+	case c.Checkbox != nil:
+		return matchCheckbox(c.Checkbox, v.Boolean), nil
+	}
+	return e.matchDate(c.Date, v.Date), nil
+}
+
+func matchText(c *TextFilterCondition, s string) bool {
+	s = strings.ToLower(s)
+	switch {
+	case c.Equals != nil:
+		return s == strings.ToLower(*c.Equals)
+	case c.DoesNotEqual != nil:
+		return s != strings.ToLower(*c.DoesNotEqual)
+	case c.Contains != nil:
+		return strings.Contains(s, strings.ToLower(*c.Contains))
+	case c.DoesNotContain != nil:
+		return !strings.Contains(s, strings.ToLower(*c.DoesNotContain))
+	case c.StartsWith != nil:
+		return strings.HasPrefix(s, strings.ToLower(*c.StartsWith))
+	case c.EndsWith != nil:
+		return strings.HasSuffix(s, strings.ToLower(*c.EndsWith))
+	case c.IsEmpty:
+		return s == ""
+	}
+	return s != ""
+}
+
+// matchNumber matches number n, empty numbers only match is_empty and does_not_equal.
+func matchNumber(c *NumberFilterCondition, n float64, empty bool) bool {
+	switch {
+	case c.IsEmpty:
+		return empty
+	case c.IsNotEmpty:
+		return !empty
+	case c.DoesNotEqual != nil:
+		return empty || n != *c.DoesNotEqual
+	case empty:
+		return false
+	case c.Equals != nil:
+		return n == *c.Equals
+	case c.GreaterThan != nil:
+		return n > *c.GreaterThan
+	case c.LessThan != nil:
+		return n < *c.LessThan
+	case c.GreaterThanOrEqualTo != nil:
+		return n >= *c.GreaterThanOrEqualTo
+	}
+	return n <= *c.LessThanOrEqualTo
+}
+
+func matchCheckbox(c *CheckboxFilterCondition, b bool) bool {
+	if c.Equals != nil {
+		return b == *c.Equals
+	}
+	return b != *c.DoesNotEqual
+}
+
+// matchContains matches conditions of lists, i.e. multi-select, people and relation.
+func matchContains(contains, doesNotContain string, isEmpty bool, values []string, equal func(a, b string) bool) bool {
+	has := func(s string) bool {
+		for _, v := range values {
+			if equal(v, s) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case contains != "":
+		return has(contains)
+	case doesNotContain != "":
+		return !has(doesNotContain)
+	case isEmpty:
+		return len(values) == 0
+	}
+	return len(values) > 0
+}
+
+func stringsEqual(a, b string) bool {
+	return a == b
+}
+
+// sameID reports whether a and b are the same ID, with or without dashes.
+func sameID(a, b string) bool {
+	return strings.EqualFold(strings.ReplaceAll(a, "-", ""), strings.ReplaceAll(b, "-", ""))
+}
+
+func (e *Evaluator) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now()
+}
+
+func (e *Evaluator) location() *time.Location {
+	if e.Location != nil {
+		return e.Location
+	}
+	return time.Local
+}
+
+// day returns the midnight in UTC of the day of t, date-only values keep their date.
+func (e *Evaluator) day(t DateTime) time.Time {
+	if !t.DateOnly {
+		t.Time = t.In(e.location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (e *Evaluator) matchDate(c *DateFilterCondition, d *Date) bool {
+	switch {
+	case c.IsEmpty:
+		return d == nil
+	case c.IsNotEmpty:
+		return d != nil
+	case d == nil:
+		return false
+	}
+	var relative time.Time
+	today := e.day(NewDateTime(e.now()))
+	switch {
+	case c.PastWeek != nil:
+		relative = today.AddDate(0, 0, -7)
+	case c.PastMonth != nil:
+		relative = today.AddDate(0, -1, 0)
+	case c.PastYear != nil:
+		relative = today.AddDate(-1, 0, 0)
+	case c.NextWeek != nil:
+		relative = today.AddDate(0, 0, 7)
+	case c.NextMonth != nil:
+		relative = today.AddDate(0, 1, 0)
+	case c.NextYear != nil:
+		relative = today.AddDate(1, 0, 0)
+	}
+	if !relative.IsZero() {
+		day := e.day(d.Start)
+		if relative.Before(today) {
+			return !day.Before(relative) && !day.After(today)
+		}
+		return !day.Before(today) && !day.After(relative)
+	}
+
+	compare := func(t *DateTime) int {
+		a, b := d.Start.Time, t.Time
+		if d.Start.DateOnly || t.DateOnly {
+			a, b = e.day(d.Start), e.day(*t)
+		}
+		switch {
+		case a.Before(b):
+			return -1
+		case a.After(b):
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case c.Equals != nil:
+		return compare(c.Equals) == 0
+	case c.Before != nil:
+		return compare(c.Before) < 0
+	case c.After != nil:
+		return compare(c.After) > 0
+	case c.OnOrBefore != nil:
+		return compare(c.OnOrBefore) <= 0
+	}
+	return compare(c.OnOrAfter) >= 0
+}
+
+// Sort sorts pages in place by sorts, earlier sorts take precedence.
+// The sort is stable, and empty values are placed last in both directions like Notion does.
+//
+// Selects are sorted by the order of options if Database is set, otherwise by name.
+// People and files are sorted by names, and relations by the number of pages.
+func (e *Evaluator) Sort(pages []*Page, sorts []*Sort) error {
+	for i, s := range sorts {
+		if err := s.validate(fmt.Sprintf("sorts[%d]", i), e.Database); err != nil {
+			return err
+		}
+	}
+	if len(sorts) == 0 {
+		return nil
+	}
+	keys := make([][]interface{}, len(pages))
+	for i, page := range pages {
+		keys[i] = make([]interface{}, len(sorts))
+		for j, s := range sorts {
+			keys[i][j] = e.sortKey(page, s)
+		}
+	}
+	index := make([]int, len(pages))
+	for i := range index {
+		index[i] = i
+	}
+	sort.SliceStable(index, func(i, j int) bool {
+		a, b := keys[index[i]], keys[index[j]]
+		for k, s := range sorts {
+			switch {
+			case a[k] == nil && b[k] == nil:
+				continue
+			case a[k] == nil:
+				return false
+			case b[k] == nil:
+				return true
+			}
+			c := compareSortKeys(a[k], b[k])
+			if c == 0 {
+				continue
+			}
+			if s.Direction == DirectionDescending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	sorted := make([]*Page, len(pages))
+	for i, j := range index {
+		sorted[i] = pages[j]
+	}
+	copy(pages, sorted)
+	return nil
+}
+
+// sortKey returns the comparable key of page for s, which is a float64, string, time.Time, a slice of them,
+// or nil for empty values.
+func (e *Evaluator) sortKey(page *Page, s *Sort) interface{} {
+	switch s.Timestamp {
+	case TimestampCreatedTime:
+		return page.CreatedTime
+	case TimestampLastEditedTime:
+		return page.LastEditedTime
+	}
+	v, ok := pageProperty(page, s.Property)
+	if !ok || v.empty() {
+		return nil
+	}
+	switch v.Type {
+	case PropertyCheckbox:
+		if v.Checkbox {
+			return float64(1)
+		}
+		return float64(0)
+	case PropertySelect:
+		return e.optionKey(s.Property, v.Select.Name)
+	case PropertyMultiSelect:
+		var key []interface{}
+		for _, o := range v.MultiSelect {
+			key = append(key, e.optionKey(s.Property, o.Name))
+		}
+		return nonEmptyKey(key)
+	case PropertyRelation:
+		if len(v.Relation) == 0 {
+			return nil
+		}
+		return float64(len(v.Relation))
+	case PropertyPeople:
+		var key []interface{}
+		for _, u := range v.People {
+			key = append(key, u.Name)
+		}
+		return nonEmptyKey(key)
+	case PropertyFile:
+		var key []interface{}
+		for _, f := range v.Files {
+			key = append(key, f.Name)
+		}
+		return nonEmptyKey(key)
+	case PropertyCreatedBy:
+		return v.CreatedBy.Name
+	case PropertyLastEditedBy:
+		return v.LastEditedBy.Name
+	case PropertyFormula:
+		if v.Formula.Type == FormulaValueBoolen {
+			if v.Formula.Boolean {
+				return float64(1)
+			}
+			return float64(0)
+		}
+	}
+	if n, ok := v.number(); ok {
+		return n
+	}
+	if d, ok := v.date(); ok {
+		return d.Start.Time
+	}
+	if s, ok := v.string(); ok && s != "" {
+		return s
+	}
+	return nil
+}
+
+func nonEmptyKey(key []interface{}) interface{} {
+	if len(key) == 0 {
+		return nil
+	}
+	return key
+}
+
+// optionKey returns the index of option in the schema of property, or its name if it isn't found.
+func (e *Evaluator) optionKey(property, name string) interface{} {
+	if e.Database == nil {
+		return name
+	}
+	p, ok := e.Database.property(property)
+	if !ok {
+		return name
+	}
+	config := p.Select
+	if p.Type == PropertyMultiSelect {
+		config = p.MultiSelect
+	}
+	if config == nil {
+		return name
+	}
+	for i, o := range config.Options {
+		if o.Name == name {
+			return float64(i)
+		}
+	}
+	return name
+}
+
+// compareSortKeys compares keys of the same kind, strings are compared case-insensitively first.
+// Keys of different kinds are ordered as numbers, times, strings and slices.
+func compareSortKeys(a, b interface{}) int {
+	if ka, kb := sortKeyKind(a), sortKeyKind(b); ka != kb {
+		return ka - kb
+	}
+	switch a := a.(type) {
+	case float64:
+		b := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case time.Time:
+		b := b.(time.Time)
+		switch {
+		case a.Before(b):
+			return -1
+		case a.After(b):
+			return 1
+		}
+	case string:
+		b := b.(string)
+		if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case []interface{}:
+		b := b.([]interface{})
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if c := compareSortKeys(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+		return len(a) - len(b)
+	}
+	return 0
+}
+
+func sortKeyKind(key interface{}) int {
+	switch key.(type) {
+	case float64:
+		return 0
+	case time.Time:
+		return 1
+	case string:
+		return 2
+	}
+	return 3
+}
@@ -0,0 +1,243 @@
+package notion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvalPage(id string, created time.Time, properties map[string]PropertyValue) *Page {
+	return &Page{ID: id, CreatedTime: created, LastEditedTime: created, Properties: properties}
+}
+
+func newTestEvalPages() []*Page {
+	day := func(d int) time.Time { return time.Date(2021, 5, d, 12, 0, 0, 0, time.UTC) }
+	date := func(d int) *Date { return &Date{Start: NewDateOnly(day(d))} }
+	return []*Page{
+		newTestEvalPage("a", day(1), map[string]PropertyValue{
+			"Name":   {Type: PropertyTitle, Title: newPlainText("Write Docs")},
+			"Score":  {Type: PropertyNumber, Number: 3},
+			"Done":   {Type: PropertyCheckbox, Checkbox: true},
+			"Status": {Type: PropertySelect, Select: &SelectOption{Name: "Done"}},
+			"Tags":   {Type: PropertyMultiSelect, MultiSelect: []*SelectOption{{Name: "docs"}}},
+			"Due":    {Type: PropertyDate, Date: date(10)},
+			"Owners": {Type: PropertyPeople, People: []*User{{ID: "1111-2222", Name: "Ann"}}},
+			"Files":  {Type: PropertyFile, Files: []*File{NewExternalFile("a.png", "https://a.png")}},
+			"Blocks": {Type: PropertyRelation, Relation: []*ObjectReference{{ID: "b"}}},
+			"Total":  {Type: PropertyFormula, Formula: &FormulaValue{Type: FormulaValueNumber, Number: 30}},
+		}),
+		newTestEvalPage("b", day(2), map[string]PropertyValue{
+			"Name":   {Type: PropertyTitle, Title: newPlainText("Fix bug")},
+			"Score":  {Type: PropertyNumber, null: true},
+			"Done":   {Type: PropertyCheckbox},
+			"Status": {Type: PropertySelect},
+			"Tags":   {Type: PropertyMultiSelect, MultiSelect: []*SelectOption{{Name: "bug"}, {Name: "docs"}}},
+			"Due":    {Type: PropertyDate, Date: date(20)},
+			"Owners": {Type: PropertyPeople},
+			"Files":  {Type: PropertyFile},
+			"Blocks": {Type: PropertyRelation},
+			"Total":  {Type: PropertyFormula, Formula: &FormulaValue{Type: FormulaValueNumber}},
+		}),
+		newTestEvalPage("c", day(3), map[string]PropertyValue{
+			"Name":   {Type: PropertyTitle},
+			"Score":  {Type: PropertyNumber, Number: 1},
+			"Done":   {Type: PropertyCheckbox},
+			"Status": {Type: PropertySelect, Select: &SelectOption{Name: "Todo"}},
+			"Tags":   {Type: PropertyMultiSelect},
+			"Due":    {Type: PropertyDate},
+			"Owners": {Type: PropertyPeople, People: []*User{{ID: "33334444", Name: "bob"}}},
+			"Files":  {Type: PropertyFile},
+			"Blocks": {Type: PropertyRelation, Relation: []*ObjectReference{{ID: "a"}, {ID: "b"}}},
+			"Total":  {Type: PropertyFormula, Formula: &FormulaValue{Type: FormulaValueNumber, Number: 10}},
+		}),
+	}
+}
+
+func pageIDs(pages []*Page) []string {
+	ids := make([]string, 0, len(pages))
+	for _, p := range pages {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func TestEvaluator_Filter(t *testing.T) {
+	now := time.Date(2021, 5, 15, 8, 0, 0, 0, time.UTC)
+	e := &Evaluator{Now: func() time.Time { return now }, Location: time.UTC}
+	tests := []struct {
+		name string
+		expr *FilterExpr
+		want []string
+	}{
+		{"text contains", Prop("Name").Text().Contains("DOC"), []string{"a"}},
+		{"text does not equal", Prop("Name").Text().DoesNotEqual("fix bug"), []string{"a", "c"}},
+		{"text starts with", Prop("Name").Text().StartsWith("fix"), []string{"b"}},
+		{"text is empty", Prop("Name").Text().IsEmpty(), []string{"c"}},
+		{"number greater than", Prop("Score").Number().GreaterThan(2), []string{"a"}},
+		{"number greater than zero", Prop("Score").Number().GreaterThan(0), []string{"a", "c"}},
+		{"text equals empty", Prop("Name").Text().Equals(""), []string{"c"}},
+		{"number does not equal", Prop("Score").Number().DoesNotEqual(3), []string{"b", "c"}},
+		{"number is empty", Prop("Score").Number().IsEmpty(), []string{"b"}},
+		{"checkbox", Prop("Done").Checkbox().Equals(false), []string{"b", "c"}},
+		{"select equals", Prop("Status").Select().Equals("Done"), []string{"a"}},
+		{"select does not equal", Prop("Status").Select().DoesNotEqual("Done"), []string{"b", "c"}},
+		{"select is empty", Prop("Status").Select().IsEmpty(), []string{"b"}},
+		{"multi-select contains", Prop("Tags").MultiSelect().Contains("docs"), []string{"a", "b"}},
+		{"multi-select does not contain", Prop("Tags").MultiSelect().DoesNotContain("bug"), []string{"a", "c"}},
+		{"date before", Prop("Due").Date().Before(time.Date(2021, 5, 20, 0, 0, 0, 0, time.UTC)), []string{"a"}},
+		{"date on or before", Prop("Due").Date().OnOrBefore(time.Date(2021, 5, 20, 23, 0, 0, 0, time.UTC)), []string{"a", "b"}},
+		{"date is empty", Prop("Due").Date().IsEmpty(), []string{"c"}},
+		{"date past week", Prop("Due").Date().PastWeek(), []string{"a"}},
+		{"date next week", Prop("Due").Date().NextWeek(), []string{"b"}},
+		{"date past year", Prop("Due").Date().PastYear(), []string{"a"}},
+		{"people contains", Prop("Owners").People().Contains("11112222"), []string{"a"}},
+		{"people is empty", Prop("Owners").People().IsEmpty(), []string{"b"}},
+		{"files is not empty", Prop("Files").Files().IsNotEmpty(), []string{"a"}},
+		{"relation contains", Prop("Blocks").Relation().Contains("b"), []string{"a", "c"}},
+		{"relation is empty", Prop("Blocks").Relation().IsEmpty(), []string{"b"}},
+		{"formula", Prop("Total").Formula().Number().GreaterThanOrEqualTo(10), []string{"a", "c"}},
+		{"created time", CreatedTime().After(time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)), []string{"b", "c"}},
+		{"last edited time", LastEditedTime().OnOrBefore(time.Date(2021, 5, 2, 0, 0, 0, 0, time.UTC)), []string{"a"}},
+		{
+			"compound",
+			Prop("Tags").MultiSelect().Contains("docs").And(Or(Prop("Done").Checkbox().Equals(true), Prop("Score").Number().IsEmpty())),
+			[]string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages, err := e.Filter(newTestEvalPages(), tt.expr.Filter())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pageIDs(pages))
+		})
+	}
+}
+
+func TestEvaluator_Filter_Error(t *testing.T) {
+	e := &Evaluator{Database: &Database{Properties: map[string]Property{"Status": NewSelectProperty()}}}
+	_, err := e.Filter(newTestEvalPages(), Prop("Stauts").Select().IsEmpty().Filter())
+	assert.EqualError(t, err, `notion: invalid filter: property "Stauts" does not exist`)
+
+	e = &Evaluator{}
+	_, err = e.Filter(newTestEvalPages(), And(Prop("Done").Checkbox().Equals(true), Prop("Status").Number().IsEmpty()).Filter())
+	assert.EqualError(t, err, `notion: invalid filter.and[1]: number condition doesn't apply to select property "Status"`)
+
+	_, err = e.Filter(newTestEvalPages(), Prop("Total").Formula().Text().IsEmpty().Filter())
+	assert.EqualError(t, err, `notion: invalid filter.formula: text condition doesn't apply to number result of formula "Total"`)
+
+	_, err = e.Filter(newTestEvalPages(), &Filter{Property: "Score"})
+	assert.IsType(t, &QueryError{}, err)
+
+	// The filter is validated once rather than per page, so it's rejected even without pages.
+	_, err = e.Filter(nil, &Filter{Property: "Score"})
+	assert.EqualError(t, err, `notion: invalid filter: no condition is set`)
+	_, err = e.Query(nil, QueryDatabaseParam{Filter: &Filter{Property: "Score"}})
+	assert.EqualError(t, err, `notion: invalid filter: no condition is set`)
+}
+
+func TestEvaluator_Filter_MissingProperty(t *testing.T) {
+	pages := newTestEvalPages()
+	for _, page := range pages[1:] {
+		delete(page.Properties, "Score")
+		delete(page.Properties, "Name")
+	}
+	tests := []struct {
+		name     string
+		database *Database
+		expr     *FilterExpr
+		want     []string
+	}{
+		{"number is empty", nil, Prop("Score").Number().IsEmpty(), []string{"b", "c"}},
+		{"number greater than", nil, Prop("Score").Number().GreaterThan(0), []string{"a"}},
+		{"number does not equal", nil, Prop("Score").Number().DoesNotEqual(3), []string{"b", "c"}},
+		{"text does not contain", nil, Prop("Name").Text().DoesNotContain("docs"), []string{"b", "c"}},
+		{"select is empty", nil, Prop("Priority").Select().IsEmpty(), []string{"a", "b", "c"}},
+		{
+			"typed by database",
+			&Database{Properties: map[string]Property{
+				"Name":  {ID: "title", Type: PropertyTitle},
+				"Score": NewNumberProperty(NumberFormatNumber),
+			}},
+			Prop("Name").Text().IsEmpty().Or(Prop("Score").Number().IsNotEmpty()),
+			[]string{"a", "b", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Evaluator{Database: tt.database}
+			result, err := e.Filter(pages, tt.expr.Filter())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pageIDs(result))
+		})
+	}
+
+	// Missing properties are still typed by the schema, so mismatched conditions are reported.
+	e := &Evaluator{Database: &Database{Properties: map[string]Property{"Score": NewNumberProperty(NumberFormatNumber)}}}
+	_, err := e.Filter(pages, Prop("Score").Text().IsEmpty().Filter())
+	assert.EqualError(t, err, `notion: invalid filter: text condition doesn't apply to number property "Score"`)
+}
+
+func TestEvaluator_Sort(t *testing.T) {
+	tests := []struct {
+		name     string
+		database *Database
+		sorts    []*Sort
+		want     []string
+	}{
+		{"none", nil, nil, []string{"a", "b", "c"}},
+		{"number ascending", nil, []*Sort{SortByProperty("Score", DirectionAscending)}, []string{"c", "a", "b"}},
+		{"number descending", nil, []*Sort{SortByProperty("Score", DirectionDescending)}, []string{"a", "c", "b"}},
+		{"title", nil, []*Sort{SortByProperty("Name", DirectionAscending)}, []string{"b", "a", "c"}},
+		{"checkbox", nil, []*Sort{SortByProperty("Done", DirectionDescending)}, []string{"a", "b", "c"}},
+		{"select by name", nil, []*Sort{SortByProperty("Status", DirectionAscending)}, []string{"a", "c", "b"}},
+		{
+			"select by options",
+			&Database{Properties: map[string]Property{
+				"Status": NewSelectProperty(NewSelectOption("Todo", ColorRed), NewSelectOption("Done", ColorGreen)),
+			}},
+			[]*Sort{SortByProperty("Status", DirectionAscending)},
+			[]string{"c", "a", "b"},
+		},
+		{"multi-select", nil, []*Sort{SortByProperty("Tags", DirectionAscending)}, []string{"b", "a", "c"}},
+		{"date", nil, []*Sort{SortByProperty("Due", DirectionDescending)}, []string{"b", "a", "c"}},
+		{"people", nil, []*Sort{SortByProperty("Owners", DirectionAscending)}, []string{"a", "c", "b"}},
+		{"relation", nil, []*Sort{SortByProperty("Blocks", DirectionDescending)}, []string{"c", "a", "b"}},
+		{"formula", nil, []*Sort{SortByProperty("Total", DirectionAscending)}, []string{"b", "c", "a"}},
+		{"created time", nil, []*Sort{SortByCreatedTime(DirectionDescending)}, []string{"c", "b", "a"}},
+		{
+			"stable with multiple sorts",
+			nil,
+			[]*Sort{SortByProperty("Done", DirectionAscending), SortByProperty("Score", DirectionDescending)},
+			[]string{"c", "b", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := newTestEvalPages()
+			e := &Evaluator{Database: tt.database}
+			require.NoError(t, e.Sort(pages, tt.sorts))
+			assert.Equal(t, tt.want, pageIDs(pages))
+		})
+	}
+
+	err := SortPages(newTestEvalPages(), []*Sort{{Property: "Score"}})
+	assert.EqualError(t, err, `notion: invalid sorts[0]: unknown direction ""`)
+}
+
+func TestEvaluator_Query(t *testing.T) {
+	e := &Evaluator{Database: &Database{Properties: map[string]Property{
+		"Score": NewNumberProperty(NumberFormatNumber),
+		"Tags":  NewMultiSelectProperty(),
+	}}}
+	pages, err := e.Query(newTestEvalPages(), QueryDatabaseParam{
+		Filter: Prop("Tags").MultiSelect().IsNotEmpty().Filter(),
+		Sorts:  []*Sort{SortByProperty("Score", DirectionAscending)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, pageIDs(pages))
+
+	_, err = e.Query(newTestEvalPages(), QueryDatabaseParam{Sorts: []*Sort{SortByProperty("Due", DirectionAscending)}})
+	assert.EqualError(t, err, `notion: invalid sorts[0]: property "Due" does not exist`)
+}
@@ -0,0 +1,117 @@
+package notion
+
+import "time"
+
+// FileType is type of file object.
+type FileType string
+
+// FileType enums.
+const (
+	// FileExternal is file hosted outside Notion.
+	FileExternal FileType = "external"
+	// FileHosted is file uploaded to Notion.
+	FileHosted FileType = "file"
+)
+
+// ExternalFile is file hosted outside Notion.
+type ExternalFile struct {
+	URL string `json:"url,omitempty"`
+}
+
+// HostedFile is file uploaded to Notion.
+// The URL is temporary and expires after ExpiryTime.
+type HostedFile struct {
+	URL        string     `json:"url,omitempty"`
+	ExpiryTime *time.Time `json:"expiry_time,omitempty"`
+}
+
+// IconType is type of Icon.
+type IconType string
+
+// IconType enums.
+const (
+	IconEmoji    IconType = "emoji"
+	IconExternal IconType = "external"
+	IconFile     IconType = "file"
+)
+
+// Icon is the icon of page, database or callout, which is either an emoji or a file.
+type Icon struct {
+	Type     IconType      `json:"type,omitempty"`
+	Emoji    string        `json:"emoji,omitempty"`
+	External *ExternalFile `json:"external,omitempty"`
+	File     *HostedFile   `json:"file,omitempty"`
+}
+
+// NewEmojiIcon creates an emoji Icon.
+func NewEmojiIcon(emoji string) *Icon {
+	return &Icon{Type: IconEmoji, Emoji: emoji}
+}
+
+// NewExternalIcon creates an Icon of external image url.
+func NewExternalIcon(url string) *Icon {
+	return &Icon{Type: IconExternal, External: &ExternalFile{URL: url}}
+}
+
+// Cover is the cover image of page or database.
+type Cover struct {
+	Type     FileType      `json:"type,omitempty"`
+	External *ExternalFile `json:"external,omitempty"`
+	File     *HostedFile   `json:"file,omitempty"`
+}
+
+// NewExternalCover creates a Cover of external image url.
+func NewExternalCover(url string) *Cover {
+	return &Cover{Type: FileExternal, External: &ExternalFile{URL: url}}
+}
+
+// File is a file uploaded to Notion or hosted externally.
+type File struct {
+	// Name is the filename of the original file upload (i.e. "Whole_Earth_Catalog.jpg").
+	Name     string        `json:"name,omitempty"`
+	Type     FileType      `json:"type,omitempty"`
+	External *ExternalFile `json:"external,omitempty"`
+	File     *HostedFile   `json:"file,omitempty"`
+
+	// owner is the page property or block containing the file, which is used to refresh expired URL.
+	owner fileOwner
+}
+
+// fileOwner locates the file in page property or block.
+type fileOwner struct {
+	pageID     string
+	propertyID string
+	// index is the position of file in page property.
+	index   int
+	blockID string
+}
+
+// NewExternalFile creates a File hosted externally.
+func NewExternalFile(name, url string) *File {
+	return &File{Name: name, Type: FileExternal, External: &ExternalFile{URL: url}}
+}
+
+// URL returns the URL of the file.
+func (f *File) URL() string {
+	if f == nil {
+		return ""
+	}
+	switch {
+	case f.File != nil:
+		return f.File.URL
+	case f.External != nil:
+		return f.External.URL
+	}
+	return ""
+}
+
+// Expired reports whether the URL of hosted file is expired or about to expire.
+func (f *File) Expired() bool {
+	if f == nil || f.File == nil || f.File.ExpiryTime == nil {
+		return false
+	}
+	return time.Now().Add(fileExpiryMargin).After(*f.File.ExpiryTime)
+}
+
+// fileExpiryMargin is the margin to refresh hosted files before they expire.
+const fileExpiryMargin = time.Minute
@@ -0,0 +1,100 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DownloadFile(t *testing.T) {
+	var srv *httptest.Server
+	hostedFile := func(name, path string, expiry time.Time) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name,
+			"type": "file",
+			"file": map[string]interface{}{"url": srv.URL + "/s3/" + path, "expiry_time": expiry},
+		}
+	}
+	var refreshed int
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/pages/page/properties/files":
+			refreshed++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"object": "property_item", "id": "files", "type": "files",
+				"files": []interface{}{
+					hostedFile("a.txt", "a.txt", time.Now().Add(time.Hour)),
+					hostedFile("a.txt", "b.png", time.Now().Add(time.Hour)),
+				},
+			})
+		case "/v1/blocks/block":
+			refreshed++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"object": "block", "id": "block", "type": "image",
+				"image": hostedFile("b.png", "b.png", time.Now().Add(time.Hour)),
+			})
+		case "/s3/a.txt", "/s3/b.png":
+			assert.Empty(t, r.Header.Get("Authorization"))
+			fmt.Fprint(w, r.URL.Path)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client := NewClient(Settings{Endpoint: srv.URL})
+	ctx := context.Background()
+	expired := time.Now().Add(-time.Hour)
+
+	b, _ := json.Marshal(map[string]interface{}{
+		"object": "page", "id": "page",
+		"properties": map[string]interface{}{
+			"Files": map[string]interface{}{"id": "files", "type": "files", "files": []interface{}{
+				hostedFile("a.txt", "a.txt", expired),
+				hostedFile("a.txt", "b.png", expired),
+			}},
+		},
+	})
+	var page Page
+	require.NoError(t, json.Unmarshal(b, &page))
+	file := page.Properties["Files"].Files[0]
+	assert.True(t, file.Expired())
+
+	var buf bytes.Buffer
+	require.NoError(t, client.DownloadFile(ctx, file, &buf))
+	assert.Equal(t, "/s3/a.txt", buf.String())
+	assert.False(t, file.Expired())
+	assert.Equal(t, 1, refreshed)
+
+	// Files of the same name are refreshed by their positions.
+	buf.Reset()
+	require.NoError(t, client.DownloadFile(ctx, page.Properties["Files"].Files[1], &buf))
+	assert.Equal(t, "/s3/b.png", buf.String())
+	assert.Equal(t, 2, refreshed)
+
+	b, _ = json.Marshal(map[string]interface{}{
+		"object": "block", "id": "block", "type": "image", "image": hostedFile("b.png", "b.png", expired),
+	})
+	var block Block
+	require.NoError(t, json.Unmarshal(b, &block))
+	buf.Reset()
+	require.NoError(t, client.DownloadFile(ctx, block.Image.Source, &buf))
+	assert.Equal(t, "/s3/b.png", buf.String())
+	assert.Equal(t, 3, refreshed)
+
+	buf.Reset()
+	require.NoError(t, client.DownloadFile(ctx, NewExternalFile("c", srv.URL+"/s3/a.txt"), &buf))
+	assert.Equal(t, "/s3/a.txt", buf.String())
+	assert.Equal(t, 3, refreshed)
+
+	orphan := &File{Type: FileHosted, File: &HostedFile{URL: srv.URL + "/s3/a.txt", ExpiryTime: &expired}}
+	assert.Error(t, client.DownloadFile(ctx, orphan, &buf))
+	assert.Error(t, client.DownloadFile(ctx, NewExternalFile("d", srv.URL+"/missing"), &buf))
+}
@@ -0,0 +1,654 @@
+package notion
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Timestamps which can be filtered and sorted on without a property.
+const (
+	TimestampCreatedTime    = "created_time"
+	TimestampLastEditedTime = "last_edited_time"
+)
+
+// QueryError reports an invalid part of a database query, Path locates it, e.g. "filter.and[1]" or "sorts[0]".
+type QueryError struct {
+	Path   string
+	Reason string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("notion: invalid %s: %s", e.Path, e.Reason)
+}
+
+// Validate checks that f is either a compound filter or a leaf with exactly one condition.
+func (f *Filter) Validate() error {
+	return f.validate("filter", nil)
+}
+
+// validate checks f recursively, leaves are also checked against the schema of db if it's not nil.
+func (f *Filter) validate(path string, db *Database) error {
+	if f == nil {
+		return &QueryError{Path: path, Reason: "filter is nil"}
+	}
+	leaf := f.Property != "" || f.Timestamp != "" || len(f.conditions()) > 0
+	switch {
+	case f.And != nil && f.Or != nil:
+		return &QueryError{Path: path, Reason: "both and and or are set"}
+	case (f.And != nil || f.Or != nil) && leaf:
+		return &QueryError{Path: path, Reason: "compound filter has property conditions"}
+	case f.And != nil:
+		return validateFilters(path+".and", f.And, db)
+	case f.Or != nil:
+		return validateFilters(path+".or", f.Or, db)
+	}
+	if err := f.validateLeaf(path); err != nil {
+		return err
+	}
+	if db != nil {
+		return f.validateSchema(path, db)
+	}
+	return nil
+}
+
+func validateFilters(path string, filters []*Filter, db *Database) error {
+	if len(filters) == 0 {
+		return &QueryError{Path: path, Reason: "compound filter is empty"}
+	}
+	for i, f := range filters {
+		if err := f.validate(fmt.Sprintf("%s[%d]", path, i), db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Filter) validateLeaf(path string) error {
+	conditions := f.conditions()
+	switch {
+	case f.Property != "" && f.Timestamp != "":
+		return &QueryError{Path: path, Reason: "both property and timestamp are set"}
+	case f.Property == "" && f.Timestamp == "":
+		return &QueryError{Path: path, Reason: "neither property nor timestamp is set"}
+	case len(conditions) == 0:
+		return &QueryError{Path: path, Reason: "no condition is set"}
+	case len(conditions) > 1:
+		return &QueryError{Path: path, Reason: fmt.Sprintf("%d conditions are set", len(conditions))}
+	}
+	name, condition := conditions[0].name, conditions[0].value
+	if f.Timestamp != "" {
+		if f.Timestamp != TimestampCreatedTime && f.Timestamp != TimestampLastEditedTime {
+			return &QueryError{Path: path, Reason: fmt.Sprintf("unknown timestamp %q", f.Timestamp)}
+		}
+		if name != f.Timestamp {
+			return &QueryError{Path: path, Reason: fmt.Sprintf("timestamp %s has %s condition", f.Timestamp, name)}
+		}
+	} else if name == TimestampCreatedTime || name == TimestampLastEditedTime {
+		return &QueryError{Path: path, Reason: fmt.Sprintf("property has %s condition", name)}
+	}
+	path += "." + name
+	if formula, ok := condition.(*FormulaFilterCondition); ok {
+		conditions = formula.conditions()
+		switch {
+		case len(conditions) == 0:
+			return &QueryError{Path: path, Reason: "no condition is set"}
+		case len(conditions) > 1:
+			return &QueryError{Path: path, Reason: fmt.Sprintf("%d conditions are set", len(conditions))}
+		}
+		path += "." + conditions[0].name
+		condition = conditions[0].value
+	}
+	if n := countNonZeroFields(condition); n != 1 {
+		return &QueryError{Path: path, Reason: fmt.Sprintf("%d operators are set, expect exactly one", n)}
+	}
+	return nil
+}
+
+// filterCondition is a non-nil condition with its JSON name.
+type filterCondition struct {
+	name  string
+	value interface{}
+}
+
+func (f *Filter) conditions() []filterCondition {
+	var conditions []filterCondition
+	add := func(name string, value interface{}, isNil bool) {
+		if !isNil {
+			conditions = append(conditions, filterCondition{name, value})
+		}
+	}
+	add("text", f.Text, f.Text == nil)
+	add("number", f.Number, f.Number == nil)
+	add("checkbox", f.Checkbox, f.Checkbox == nil)
+	add("select", f.Select, f.Select == nil)
+	add("multi_select", f.MultiSelect, f.MultiSelect == nil)
+	add("date", f.Date, f.Date == nil)
+	add("people", f.People, f.People == nil)
+	add("files", f.Files, f.Files == nil)
+	add("relation", f.Relation, f.Relation == nil)
+	add("formula", f.Formula, f.Formula == nil)
+	add(TimestampCreatedTime, f.CreatedTime, f.CreatedTime == nil)
+	add(TimestampLastEditedTime, f.LastEditedTime, f.LastEditedTime == nil)
+	return conditions
+}
+
+func (c *FormulaFilterCondition) conditions() []filterCondition {
+	var conditions []filterCondition
+	if c.Text != nil {
+		conditions = append(conditions, filterCondition{"text", c.Text})
+	}
+	if c.Checkbox != nil {
+		conditions = append(conditions, filterCondition{"checkbox", c.Checkbox})
+	}
+	if c.Number != nil {
+		conditions = append(conditions, filterCondition{"number", c.Number})
+	}
+	if c.Date != nil {
+		conditions = append(conditions, filterCondition{"date", c.Date})
+	}
+	return conditions
+}
+
+// countNonZeroFields counts the operators set in a condition struct pointer.
+func countNonZeroFields(condition interface{}) int {
+	v := reflect.ValueOf(condition).Elem()
+	n := 0
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsZero() {
+			n++
+		}
+	}
+	return n
+}
+
+// FilterExpr is a filter under construction, created by Prop, CreatedTime, LastEditedTime, And and Or.
+//
+//	filter, err := notion.Prop("Status").Select().Equals("Done").
+//		And(notion.Prop("Due").Date().Before(time.Now())).
+//		Build()
+type FilterExpr struct {
+	filter *Filter
+}
+
+// NewFilterExpr wraps an existing filter, so it can be combined with others.
+func NewFilterExpr(filter *Filter) *FilterExpr {
+	return &FilterExpr{filter: filter}
+}
+
+// And combines exprs with e, all of them must match.
+func (e *FilterExpr) And(exprs ...*FilterExpr) *FilterExpr {
+	return And(append([]*FilterExpr{e}, exprs...)...)
+}
+
+// Or combines exprs with e, any of them must match.
+func (e *FilterExpr) Or(exprs ...*FilterExpr) *FilterExpr {
+	return Or(append([]*FilterExpr{e}, exprs...)...)
+}
+
+// Build validates and returns the filter.
+func (e *FilterExpr) Build() (*Filter, error) {
+	if err := e.filter.Validate(); err != nil {
+		return nil, err
+	}
+	return e.filter, nil
+}
+
+// Filter returns the filter without validation.
+func (e *FilterExpr) Filter() *Filter {
+	return e.filter
+}
+
+// And creates a compound filter which matches if all exprs match.
+// Nested "and" filters are flattened.
+func And(exprs ...*FilterExpr) *FilterExpr {
+	filters := make([]*Filter, 0, len(exprs))
+	for _, e := range exprs {
+		if isCompound(e.filter) && e.filter.And != nil {
+			filters = append(filters, e.filter.And...)
+			continue
+		}
+		filters = append(filters, e.filter)
+	}
+	return &FilterExpr{filter: &Filter{And: filters}}
+}
+
+// Or creates a compound filter which matches if any of exprs matches.
+// Nested "or" filters are flattened.
+func Or(exprs ...*FilterExpr) *FilterExpr {
+	filters := make([]*Filter, 0, len(exprs))
+	for _, e := range exprs {
+		if isCompound(e.filter) && e.filter.Or != nil {
+			filters = append(filters, e.filter.Or...)
+			continue
+		}
+		filters = append(filters, e.filter)
+	}
+	return &FilterExpr{filter: &Filter{Or: filters}}
+}
+
+func isCompound(f *Filter) bool {
+	return f != nil && (f.And != nil) != (f.Or != nil) && f.Property == "" && f.Timestamp == "" && len(f.conditions()) == 0
+}
+
+// PropertyFilterBuilder chooses the condition type of a property filter.
+type PropertyFilterBuilder struct {
+	name string
+}
+
+// Prop starts a filter on the property with name.
+func Prop(name string) PropertyFilterBuilder {
+	return PropertyFilterBuilder{name: name}
+}
+
+// Text filters properties of types "title", "rich_text", "url", "email", and "phone".
+func (p PropertyFilterBuilder) Text() TextFilterBuilder {
+	return TextFilterBuilder{build: func(c *TextFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, Text: c}}
+	}}
+}
+
+// Number filters properties of type "number".
+func (p PropertyFilterBuilder) Number() NumberFilterBuilder {
+	return NumberFilterBuilder{build: func(c *NumberFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, Number: c}}
+	}}
+}
+
+// Checkbox filters properties of type "checkbox".
+func (p PropertyFilterBuilder) Checkbox() CheckboxFilterBuilder {
+	return CheckboxFilterBuilder{build: func(c *CheckboxFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, Checkbox: c}}
+	}}
+}
+
+// Select filters properties of type "select".
+func (p PropertyFilterBuilder) Select() SelectFilterBuilder {
+	return SelectFilterBuilder{build: func(c *SelectFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, Select: c}}
+	}}
+}
+
+// MultiSelect filters properties of type "multi_select".
+func (p PropertyFilterBuilder) MultiSelect() MultiSelectFilterBuilder {
+	return MultiSelectFilterBuilder{build: func(c *MultiSelectFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, MultiSelect: c}}
+	}}
+}
+
+// Date filters properties of types "date", "created_time", and "last_edited_time".
+func (p PropertyFilterBuilder) Date() DateFilterBuilder {
+	return DateFilterBuilder{build: func(c *DateFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, Date: c}}
+	}}
+}
+
+// People filters properties of types "people", "created_by", and "last_edited_by".
+func (p PropertyFilterBuilder) People() PeopleFilterBuilder {
+	return PeopleFilterBuilder{build: func(c *PeopleFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, People: c}}
+	}}
+}
+
+// Files filters properties of type "files".
+func (p PropertyFilterBuilder) Files() FilesFilterBuilder {
+	return FilesFilterBuilder{build: func(c *FilesFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, Files: c}}
+	}}
+}
+
+// Relation filters properties of type "relation".
+func (p PropertyFilterBuilder) Relation() RelationFilterBuilder {
+	return RelationFilterBuilder{build: func(c *RelationFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, Relation: c}}
+	}}
+}
+
+// Formula filters properties of type "formula" by the type of their results.
+func (p PropertyFilterBuilder) Formula() FormulaFilterBuilder {
+	return FormulaFilterBuilder{build: func(c *FormulaFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Property: p.name, Formula: c}}
+	}}
+}
+
+// CreatedTime starts a filter on the creation time of pages.
+func CreatedTime() DateFilterBuilder {
+	return DateFilterBuilder{build: func(c *DateFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Timestamp: TimestampCreatedTime, CreatedTime: c}}
+	}}
+}
+
+// LastEditedTime starts a filter on the last edited time of pages.
+func LastEditedTime() DateFilterBuilder {
+	return DateFilterBuilder{build: func(c *DateFilterCondition) *FilterExpr {
+		return &FilterExpr{filter: &Filter{Timestamp: TimestampLastEditedTime, LastEditedTime: c}}
+	}}
+}
+
+// FormulaFilterBuilder chooses the result type of a formula filter.
+type FormulaFilterBuilder struct {
+	build func(*FormulaFilterCondition) *FilterExpr
+}
+
+// Text filters formulas with string results.
+func (b FormulaFilterBuilder) Text() TextFilterBuilder {
+	return TextFilterBuilder{build: func(c *TextFilterCondition) *FilterExpr {
+		return b.build(&FormulaFilterCondition{Text: c})
+	}}
+}
+
+// Number filters formulas with number results.
+func (b FormulaFilterBuilder) Number() NumberFilterBuilder {
+	return NumberFilterBuilder{build: func(c *NumberFilterCondition) *FilterExpr {
+		return b.build(&FormulaFilterCondition{Number: c})
+	}}
+}
+
+// Checkbox filters formulas with boolean results.
+func (b FormulaFilterBuilder) Checkbox() CheckboxFilterBuilder {
+	return CheckboxFilterBuilder{build: func(c *CheckboxFilterCondition) *FilterExpr {
+		return b.build(&FormulaFilterCondition{Checkbox: c})
+	}}
+}
+
+// Date filters formulas with date results.
+func (b FormulaFilterBuilder) Date() DateFilterBuilder {
+	return DateFilterBuilder{build: func(c *DateFilterCondition) *FilterExpr {
+		return b.build(&FormulaFilterCondition{Date: c})
+	}}
+}
+
+// TextFilterBuilder builds TextFilterCondition.
+type TextFilterBuilder struct {
+	build func(*TextFilterCondition) *FilterExpr
+}
+
+// Equals matches texts equal to s.
+func (b TextFilterBuilder) Equals(s string) *FilterExpr {
+	return b.build(&TextFilterCondition{Equals: &s})
+}
+
+// DoesNotEqual matches texts not equal to s.
+func (b TextFilterBuilder) DoesNotEqual(s string) *FilterExpr {
+	return b.build(&TextFilterCondition{DoesNotEqual: &s})
+}
+
+// Contains matches texts containing s.
+func (b TextFilterBuilder) Contains(s string) *FilterExpr {
+	return b.build(&TextFilterCondition{Contains: &s})
+}
+
+// DoesNotContain matches texts not containing s.
+func (b TextFilterBuilder) DoesNotContain(s string) *FilterExpr {
+	return b.build(&TextFilterCondition{DoesNotContain: &s})
+}
+
+// StartsWith matches texts starting with s.
+func (b TextFilterBuilder) StartsWith(s string) *FilterExpr {
+	return b.build(&TextFilterCondition{StartsWith: &s})
+}
+
+// EndsWith matches texts ending with s.
+func (b TextFilterBuilder) EndsWith(s string) *FilterExpr {
+	return b.build(&TextFilterCondition{EndsWith: &s})
+}
+
+// IsEmpty matches empty texts.
+func (b TextFilterBuilder) IsEmpty() *FilterExpr {
+	return b.build(&TextFilterCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty texts.
+func (b TextFilterBuilder) IsNotEmpty() *FilterExpr {
+	return b.build(&TextFilterCondition{IsNotEmpty: true})
+}
+
+// NumberFilterBuilder builds NumberFilterCondition.
+type NumberFilterBuilder struct {
+	build func(*NumberFilterCondition) *FilterExpr
+}
+
+// Equals matches numbers equal to n.
+func (b NumberFilterBuilder) Equals(n float64) *FilterExpr {
+	return b.build(&NumberFilterCondition{Equals: &n})
+}
+
+// DoesNotEqual matches numbers not equal to n.
+func (b NumberFilterBuilder) DoesNotEqual(n float64) *FilterExpr {
+	return b.build(&NumberFilterCondition{DoesNotEqual: &n})
+}
+
+// GreaterThan matches numbers greater than n.
+func (b NumberFilterBuilder) GreaterThan(n float64) *FilterExpr {
+	return b.build(&NumberFilterCondition{GreaterThan: &n})
+}
+
+// LessThan matches numbers less than n.
+func (b NumberFilterBuilder) LessThan(n float64) *FilterExpr {
+	return b.build(&NumberFilterCondition{LessThan: &n})
+}
+
+// GreaterThanOrEqualTo matches numbers greater than or equal to n.
+func (b NumberFilterBuilder) GreaterThanOrEqualTo(n float64) *FilterExpr {
+	return b.build(&NumberFilterCondition{GreaterThanOrEqualTo: &n})
+}
+
+// LessThanOrEqualTo matches numbers less than or equal to n.
+func (b NumberFilterBuilder) LessThanOrEqualTo(n float64) *FilterExpr {
+	return b.build(&NumberFilterCondition{LessThanOrEqualTo: &n})
+}
+
+// IsEmpty matches empty numbers.
+func (b NumberFilterBuilder) IsEmpty() *FilterExpr {
+	return b.build(&NumberFilterCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty numbers.
+func (b NumberFilterBuilder) IsNotEmpty() *FilterExpr {
+	return b.build(&NumberFilterCondition{IsNotEmpty: true})
+}
+
+// CheckboxFilterBuilder builds CheckboxFilterCondition.
+type CheckboxFilterBuilder struct {
+	build func(*CheckboxFilterCondition) *FilterExpr
+}
+
+// Equals matches checkboxes in state v.
+func (b CheckboxFilterBuilder) Equals(v bool) *FilterExpr {
+	return b.build(&CheckboxFilterCondition{Equals: &v})
+}
+
+// DoesNotEqual matches checkboxes not in state v.
+func (b CheckboxFilterBuilder) DoesNotEqual(v bool) *FilterExpr {
+	return b.build(&CheckboxFilterCondition{DoesNotEqual: &v})
+}
+
+// SelectFilterBuilder builds SelectFilterCondition.
+type SelectFilterBuilder struct {
+	build func(*SelectFilterCondition) *FilterExpr
+}
+
+// Equals matches the option named s.
+func (b SelectFilterBuilder) Equals(s string) *FilterExpr {
+	return b.build(&SelectFilterCondition{Equals: s})
+}
+
+// DoesNotEqual matches options other than s.
+func (b SelectFilterBuilder) DoesNotEqual(s string) *FilterExpr {
+	return b.build(&SelectFilterCondition{DoesNotEqual: s})
+}
+
+// IsEmpty matches empty selects.
+func (b SelectFilterBuilder) IsEmpty() *FilterExpr {
+	return b.build(&SelectFilterCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty selects.
+func (b SelectFilterBuilder) IsNotEmpty() *FilterExpr {
+	return b.build(&SelectFilterCondition{IsNotEmpty: true})
+}
+
+// MultiSelectFilterBuilder builds MultiSelectFilterCondition.
+type MultiSelectFilterBuilder struct {
+	build func(*MultiSelectFilterCondition) *FilterExpr
+}
+
+// Contains matches multi-selects including the option named s.
+func (b MultiSelectFilterBuilder) Contains(s string) *FilterExpr {
+	return b.build(&MultiSelectFilterCondition{Contains: s})
+}
+
+// DoesNotContain matches multi-selects excluding the option named s.
+func (b MultiSelectFilterBuilder) DoesNotContain(s string) *FilterExpr {
+	return b.build(&MultiSelectFilterCondition{DoesNotContain: s})
+}
+
+// IsEmpty matches empty multi-selects.
+func (b MultiSelectFilterBuilder) IsEmpty() *FilterExpr {
+	return b.build(&MultiSelectFilterCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty multi-selects.
+func (b MultiSelectFilterBuilder) IsNotEmpty() *FilterExpr {
+	return b.build(&MultiSelectFilterCondition{IsNotEmpty: true})
+}
+
+// DateFilterBuilder builds DateFilterCondition.
+type DateFilterBuilder struct {
+	build func(*DateFilterCondition) *FilterExpr
+}
+
+func newDateTimeRef(t time.Time) *DateTime {
+	v := NewDateTime(t)
+	return &v
+}
+
+// Equals matches dates equal to t.
+func (b DateFilterBuilder) Equals(t time.Time) *FilterExpr {
+	return b.build(&DateFilterCondition{Equals: newDateTimeRef(t)})
+}
+
+// Before matches dates before t.
+func (b DateFilterBuilder) Before(t time.Time) *FilterExpr {
+	return b.build(&DateFilterCondition{Before: newDateTimeRef(t)})
+}
+
+// After matches dates after t.
+func (b DateFilterBuilder) After(t time.Time) *FilterExpr {
+	return b.build(&DateFilterCondition{After: newDateTimeRef(t)})
+}
+
+// OnOrBefore matches dates on or before t.
+func (b DateFilterBuilder) OnOrBefore(t time.Time) *FilterExpr {
+	return b.build(&DateFilterCondition{OnOrBefore: newDateTimeRef(t)})
+}
+
+// OnOrAfter matches dates on or after t.
+func (b DateFilterBuilder) OnOrAfter(t time.Time) *FilterExpr {
+	return b.build(&DateFilterCondition{OnOrAfter: newDateTimeRef(t)})
+}
+
+// IsEmpty matches empty dates.
+func (b DateFilterBuilder) IsEmpty() *FilterExpr {
+	return b.build(&DateFilterCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty dates.
+func (b DateFilterBuilder) IsNotEmpty() *FilterExpr {
+	return b.build(&DateFilterCondition{IsNotEmpty: true})
+}
+
+// PastWeek matches dates within the past week.
+func (b DateFilterBuilder) PastWeek() *FilterExpr {
+	return b.build(&DateFilterCondition{PastWeek: &struct{}{}})
+}
+
+// PastMonth matches dates within the past month.
+func (b DateFilterBuilder) PastMonth() *FilterExpr {
+	return b.build(&DateFilterCondition{PastMonth: &struct{}{}})
+}
+
+// PastYear matches dates within the past year.
+func (b DateFilterBuilder) PastYear() *FilterExpr {
+	return b.build(&DateFilterCondition{PastYear: &struct{}{}})
+}
+
+// NextWeek matches dates within the next week.
+func (b DateFilterBuilder) NextWeek() *FilterExpr {
+	return b.build(&DateFilterCondition{NextWeek: &struct{}{}})
+}
+
+// NextMonth matches dates within the next month.
+func (b DateFilterBuilder) NextMonth() *FilterExpr {
+	return b.build(&DateFilterCondition{NextMonth: &struct{}{}})
+}
+
+// NextYear matches dates within the next year.
+func (b DateFilterBuilder) NextYear() *FilterExpr {
+	return b.build(&DateFilterCondition{NextYear: &struct{}{}})
+}
+
+// PeopleFilterBuilder builds PeopleFilterCondition.
+type PeopleFilterBuilder struct {
+	build func(*PeopleFilterCondition) *FilterExpr
+}
+
+// Contains matches people including the user with id.
+func (b PeopleFilterBuilder) Contains(id string) *FilterExpr {
+	return b.build(&PeopleFilterCondition{Contains: id})
+}
+
+// DoesNotContain matches people excluding the user with id.
+func (b PeopleFilterBuilder) DoesNotContain(id string) *FilterExpr {
+	return b.build(&PeopleFilterCondition{DoesNotContain: id})
+}
+
+// IsEmpty matches empty people.
+func (b PeopleFilterBuilder) IsEmpty() *FilterExpr {
+	return b.build(&PeopleFilterCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty people.
+func (b PeopleFilterBuilder) IsNotEmpty() *FilterExpr {
+	return b.build(&PeopleFilterCondition{IsNotEmpty: true})
+}
+
+// FilesFilterBuilder builds FilesFilterCondition.
+type FilesFilterBuilder struct {
+	build func(*FilesFilterCondition) *FilterExpr
+}
+
+// IsEmpty matches empty files.
+func (b FilesFilterBuilder) IsEmpty() *FilterExpr {
+	return b.build(&FilesFilterCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty files.
+func (b FilesFilterBuilder) IsNotEmpty() *FilterExpr {
+	return b.build(&FilesFilterCondition{IsNotEmpty: true})
+}
+
+// RelationFilterBuilder builds RelationFilterCondition.
+type RelationFilterBuilder struct {
+	build func(*RelationFilterCondition) *FilterExpr
+}
+
+// Contains matches relations including the page with id.
+func (b RelationFilterBuilder) Contains(id string) *FilterExpr {
+	return b.build(&RelationFilterCondition{Contains: id})
+}
+
+// DoesNotContain matches relations excluding the page with id.
+func (b RelationFilterBuilder) DoesNotContain(id string) *FilterExpr {
+	return b.build(&RelationFilterCondition{DoesNotContain: id})
+}
+
+// IsEmpty matches empty relations.
+func (b RelationFilterBuilder) IsEmpty() *FilterExpr {
+	return b.build(&RelationFilterCondition{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty relations.
+func (b RelationFilterBuilder) IsNotEmpty() *FilterExpr {
+	return b.build(&RelationFilterCondition{IsNotEmpty: true})
+}
@@ -0,0 +1,141 @@
+package notion
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterExpr_Build(t *testing.T) {
+	due := time.Date(2021, 5, 13, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		expr *FilterExpr
+		want string
+	}{
+		{
+			name: "select",
+			expr: Prop("Status").Select().Equals("Done"),
+			want: `{"property":"Status","select":{"equals":"Done"}}`,
+		},
+		{
+			name: "and",
+			expr: Prop("Status").Select().Equals("Done").And(Prop("Due").Date().Before(due)),
+			want: `{"and":[{"property":"Status","select":{"equals":"Done"}},{"property":"Due","date":{"before":"2021-05-13T00:00:00Z"}}]}`,
+		},
+		{
+			name: "flatten",
+			expr: Prop("A").Text().Contains("a").Or(Prop("B").Number().GreaterThan(1)).Or(Prop("C").Files().IsEmpty()),
+			want: `{"or":[{"property":"A","text":{"contains":"a"}},{"property":"B","number":{"greater_than":1}},{"property":"C","files":{"is_empty":true}}]}`,
+		},
+		{
+			name: "nested",
+			expr: And(Prop("A").People().IsNotEmpty(), Or(Prop("B").MultiSelect().Contains("x"), Prop("C").Relation().Contains("id"))),
+			want: `{"and":[{"property":"A","people":{"is_not_empty":true}},{"or":[{"property":"B","multi_select":{"contains":"x"}},{"property":"C","relation":{"contains":"id"}}]}]}`,
+		},
+		{
+			name: "checkbox false",
+			expr: Prop("Done").Checkbox().Equals(false),
+			want: `{"property":"Done","checkbox":{"equals":false}}`,
+		},
+		{
+			name: "zero number",
+			expr: Prop("Score").Number().GreaterThan(0),
+			want: `{"property":"Score","number":{"greater_than":0}}`,
+		},
+		{
+			name: "empty text",
+			expr: Prop("Name").Text().Equals(""),
+			want: `{"property":"Name","text":{"equals":""}}`,
+		},
+		{
+			name: "formula",
+			expr: Prop("Score").Formula().Number().LessThanOrEqualTo(3),
+			want: `{"property":"Score","formula":{"number":{"less_than_or_equal_to":3}}}`,
+		},
+		{
+			name: "created time",
+			expr: CreatedTime().PastWeek(),
+			want: `{"timestamp":"created_time","created_time":{"past_week":{}}}`,
+		},
+		{
+			name: "last edited time",
+			expr: LastEditedTime().OnOrAfter(due),
+			want: `{"timestamp":"last_edited_time","last_edited_time":{"on_or_after":"2021-05-13T00:00:00Z"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := tt.expr.Build()
+			require.NoError(t, err)
+			b, err := json.Marshal(filter)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(b))
+		})
+	}
+}
+
+func TestFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *Filter
+		wantErr string
+	}{
+		{
+			name:    "two conditions",
+			filter:  &Filter{Property: "A", Text: Prop("A").Text().Equals("a").Filter().Text, Select: &SelectFilterCondition{Equals: "a"}},
+			wantErr: "notion: invalid filter: 2 conditions are set",
+		},
+		{
+			name:    "two operators",
+			filter:  &Filter{Property: "A", Text: &TextFilterCondition{Contains: new(string), IsEmpty: true}},
+			wantErr: "notion: invalid filter.text: 2 operators are set, expect exactly one",
+		},
+		{
+			name:    "no operator",
+			filter:  &Filter{Property: "A", Number: &NumberFilterCondition{}},
+			wantErr: "notion: invalid filter.number: 0 operators are set, expect exactly one",
+		},
+		{
+			name:    "no property",
+			filter:  &Filter{Text: Prop("A").Text().Equals("a").Filter().Text},
+			wantErr: "notion: invalid filter: neither property nor timestamp is set",
+		},
+		{
+			name:    "nested",
+			filter:  And(Prop("A").Text().IsEmpty(), Or(Prop("B").Text().IsEmpty(), NewFilterExpr(&Filter{Property: "C"}))).Filter(),
+			wantErr: "notion: invalid filter.and[1].or[1]: no condition is set",
+		},
+		{
+			name:    "formula",
+			filter:  &Filter{Property: "A", Formula: &FormulaFilterCondition{}},
+			wantErr: "notion: invalid filter.formula: no condition is set",
+		},
+		{
+			name:    "timestamp mismatch",
+			filter:  &Filter{Timestamp: TimestampCreatedTime, LastEditedTime: &DateFilterCondition{PastWeek: &struct{}{}}},
+			wantErr: "notion: invalid filter: timestamp created_time has last_edited_time condition",
+		},
+		{
+			name:    "mixed compound",
+			filter:  &Filter{Property: "A", And: []*Filter{{Property: "B", Text: &TextFilterCondition{IsEmpty: true}}}},
+			wantErr: "notion: invalid filter: compound filter has property conditions",
+		},
+		{
+			name:    "empty compound",
+			filter:  &Filter{Or: []*Filter{}},
+			wantErr: "notion: invalid filter.or: compound filter is empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			require.Error(t, err)
+			assert.IsType(t, &QueryError{}, err)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
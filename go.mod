@@ -4,5 +4,5 @@ go 1.16
 
 require (
 	github.com/stretchr/testify v1.7.0
-	github.com/yuin/goldmark v1.3.6 // indirect
+	github.com/yuin/goldmark v1.3.6
 )
@@ -0,0 +1,389 @@
+// Package html renders Notion rich texts and blocks to HTML.
+package html
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sorcererxw/go-notion"
+)
+
+// MentionMode decides how page and database mentions are rendered.
+type MentionMode int
+
+// MentionMode enums.
+const (
+	// MentionLink renders mentions as links to PageURL.
+	MentionLink MentionMode = iota
+	// MentionInline renders mentions as the title of the mentioned page without link.
+	MentionInline
+)
+
+// BlockData is the data passed to block templates.
+type BlockData struct {
+	Block *notion.Block
+	// Text is the rendered rich text of block, i.e. the text of paragraph.
+	Text template.HTML
+	// Caption is the rendered caption of code, file and bookmark blocks.
+	Caption template.HTML
+	// Children is the rendered children of block.
+	Children template.HTML
+	// Color is the class of the color of quote and callout blocks, empty for default color.
+	Color string
+	// URL is the URL of file, bookmark and embed blocks, or the URL of the page for child pages and links to page.
+	URL string
+	// Title is the title of child pages and child databases, the name of files, or the plain text of caption of images.
+	Title string
+	// Cells is the rendered cells of table rows.
+	Cells []template.HTML
+	// Header reports whether the table row is the column header.
+	Header bool
+}
+
+// Renderer renders Notion rich texts and blocks to safe HTML, all texts are escaped.
+// The zero value is ready to use.
+type Renderer struct {
+	// Templates overrides templates of blocks by type, the templates are executed with *BlockData.
+	Templates map[notion.BlockType]*template.Template
+	// Mentions decides how page and database mentions are rendered.
+	Mentions MentionMode
+	// PageURL returns the URL of page or database, which is used by mentions, child pages and links to page.
+	// Default is the URL of the page on Notion.
+	PageURL func(id string) string
+}
+
+var defaultTemplates = map[notion.BlockType]*template.Template{}
+
+func init() {
+	for t, text := range map[notion.BlockType]string{
+		notion.BlockParagraph:        `<p>{{.Text}}</p>{{with .Children}}<div class="notion-children">{{.}}</div>{{end}}`,
+		notion.BlockHeading1:         `<h1>{{.Text}}</h1>`,
+		notion.BlockHeading2:         `<h2>{{.Text}}</h2>`,
+		notion.BlockHeading3:         `<h3>{{.Text}}</h3>`,
+		notion.BlockBulletedListItem: `<li>{{.Text}}{{.Children}}</li>`,
+		notion.BlockNumberedListItem: `<li>{{.Text}}{{.Children}}</li>`,
+		notion.BlockToDo:             `<li><input type="checkbox" disabled{{if .Block.ToDo.Checked}} checked{{end}}> {{.Text}}{{.Children}}</li>`,
+		notion.BlockToggle:           `<details><summary>{{.Text}}</summary>{{.Children}}</details>`,
+		notion.BlockCode:             `<pre><code{{with .Block.Code.Language}}{{if ne . "plain text"}} class="language-{{.}}"{{end}}{{end}}>{{.Text}}</code></pre>{{with .Caption}}<p class="notion-caption">{{.}}</p>{{end}}`,
+		notion.BlockQuote:            `<blockquote{{with .Color}} class="{{.}}"{{end}}>{{.Text}}{{.Children}}</blockquote>`,
+		notion.BlockCallout:          `<div class="notion-callout{{with .Color}} {{.}}{{end}}">{{with .Block.Callout.Icon}}{{with .Emoji}}<span class="notion-callout-icon">{{.}}</span>{{end}}{{end}}<div>{{.Text}}{{.Children}}</div></div>`,
+		notion.BlockDivider:          `<hr>`,
+		notion.BlockImage:            `<figure><img src="{{.URL}}" alt="{{.Title}}">{{with .Caption}}<figcaption>{{.}}</figcaption>{{end}}</figure>`,
+		notion.BlockVideo:            `<figure><video src="{{.URL}}" controls></video>{{with .Caption}}<figcaption>{{.}}</figcaption>{{end}}</figure>`,
+		notion.BlockFile:             `<p class="notion-file"><a href="{{.URL}}">{{if .Caption}}{{.Caption}}{{else}}{{.Title}}{{end}}</a></p>`,
+		notion.BlockPDF:              `<p class="notion-file"><a href="{{.URL}}">{{if .Caption}}{{.Caption}}{{else}}{{.Title}}{{end}}</a></p>`,
+		notion.BlockBookmark:         `<p class="notion-bookmark"><a href="{{.URL}}">{{if .Caption}}{{.Caption}}{{else}}{{.URL}}{{end}}</a></p>`,
+		notion.BlockEmbed:            `<p class="notion-embed"><a href="{{.URL}}">{{if .Caption}}{{.Caption}}{{else}}{{.URL}}{{end}}</a></p>`,
+		notion.BlockEquation:         `<div class="notion-equation">{{.Block.Equation.Expression}}</div>`,
+		notion.BlockColumnList:       `<div class="notion-column-list">{{.Children}}</div>`,
+		notion.BlockColumn:           `<div class="notion-column">{{.Children}}</div>`,
+		notion.BlockLinkToPage:       `<p class="notion-page"><a href="{{.URL}}">{{.Title}}</a></p>`,
+		notion.BlockSyncedBlock:      `{{.Children}}`,
+		notion.BlockTable:            `<table class="notion-table"><tbody>{{.Children}}</tbody></table>`,
+		notion.BlockTableRow:         `<tr>{{range .Cells}}{{if $.Header}}<th>{{.}}</th>{{else}}<td>{{.}}</td>{{end}}{{end}}</tr>`,
+		notion.BlockChildPage:        `<p class="notion-page"><a href="{{.URL}}">{{.Title}}</a></p>`,
+		notion.BlockChildDatabase:    `<p class="notion-database"><a href="{{.URL}}">{{.Title}}</a></p>`,
+	} {
+		defaultTemplates[t] = template.Must(template.New(string(t)).Parse(text))
+	}
+}
+
+// RenderBlockChildren retrieves the block tree under block by notion.RetrieveBlockTree, and renders it.
+func (r *Renderer) RenderBlockChildren(ctx context.Context, api notion.API, blockID string) (template.HTML, error) {
+	blocks, err := notion.RetrieveBlockTree(ctx, api, blockID)
+	if err != nil {
+		return "", err
+	}
+	return r.RenderBlocks(blocks)
+}
+
+// RenderBlocks renders blocks and their children, adjacent list items are wrapped with <ul> or <ol>.
+// Blocks without template are skipped, i.e. table of contents, breadcrumb and unsupported blocks.
+func (r *Renderer) RenderBlocks(blocks []*notion.Block) (template.HTML, error) {
+	var b bytes.Buffer
+	list := ""
+	for _, block := range blocks {
+		if tag := listTag(block.Type); tag != list {
+			if list != "" {
+				b.WriteString("</" + strings.Fields(list)[0] + ">")
+			}
+			if tag != "" {
+				b.WriteString("<" + tag + ">")
+			}
+			list = tag
+		}
+		if err := r.renderBlock(&b, block, false); err != nil {
+			return "", err
+		}
+	}
+	if list != "" {
+		b.WriteString("</" + strings.Fields(list)[0] + ">")
+	}
+	return template.HTML(b.String()), nil
+}
+
+// listTag returns the opening tag of list wrapping items of the block type, empty if not a list item.
+func listTag(t notion.BlockType) string {
+	switch t {
+	case notion.BlockBulletedListItem:
+		return "ul"
+	case notion.BlockNumberedListItem:
+		return "ol"
+	case notion.BlockToDo:
+		return `ul class="notion-to-do-list"`
+	}
+	return ""
+}
+
+func (r *Renderer) renderBlock(b *bytes.Buffer, block *notion.Block, header bool) error {
+	tmpl := r.Templates[block.Type]
+	if tmpl == nil {
+		tmpl = defaultTemplates[block.Type]
+	}
+	if tmpl == nil || block.Content() == nil {
+		return nil
+	}
+	data, err := r.blockData(block)
+	if err != nil {
+		return err
+	}
+	data.Header = header
+	if err := tmpl.Execute(b, data); err != nil {
+		return fmt.Errorf("html: render %s block %q: %w", block.Type, block.ID, err)
+	}
+	return nil
+}
+
+func (r *Renderer) blockData(block *notion.Block) (*BlockData, error) {
+	data := &BlockData{Block: block}
+	switch c := block.Content().(type) {
+	case *notion.Paragraph:
+		data.Text = r.RenderRichText(c.Text)
+	case *notion.Heading:
+		data.Text = r.RenderRichText(c.Text)
+	case *notion.ListItem:
+		data.Text = r.RenderRichText(c.Text)
+	case *notion.ToDo:
+		data.Text = r.RenderRichText(c.Text)
+	case *notion.Toggle:
+		data.Text = r.RenderRichText(c.Text)
+	case *notion.Code:
+		data.Text = template.HTML(template.HTMLEscapeString(plainText(c.Text)))
+		data.Caption = r.RenderRichText(c.Caption)
+	case *notion.Quote:
+		data.Text = r.RenderRichText(c.Text)
+		data.Color = colorClass(c.Color)
+	case *notion.Callout:
+		data.Text = r.RenderRichText(c.Text)
+		data.Color = colorClass(c.Color)
+	case *notion.FileBlock:
+		data.URL = c.Source.URL()
+		data.Caption = r.RenderRichText(c.Caption)
+		if c.Source != nil {
+			data.Title = c.Source.Name
+		}
+		if block.Type == notion.BlockImage {
+			data.Title = plainText(c.Caption)
+		} else if data.Title == "" {
+			data.Title = data.URL
+		}
+	case *notion.Bookmark:
+		data.URL = c.URL
+		data.Caption = r.RenderRichText(c.Caption)
+	case *notion.Embed:
+		data.URL = c.URL
+		data.Caption = r.RenderRichText(c.Caption)
+	case *notion.LinkToPage:
+		id := c.PageID
+		if id == "" {
+			id = c.DatabaseID
+		}
+		data.URL = r.pageURL(id, "")
+		data.Title = id
+	case *notion.ChildPage:
+		data.URL = r.pageURL(block.ID, "")
+		data.Title = c.Title
+	case *notion.ChildDatabase:
+		data.URL = r.pageURL(block.ID, "")
+		data.Title = c.Title
+	case *notion.Table:
+		var b bytes.Buffer
+		for i, row := range c.Children {
+			if err := r.renderBlock(&b, row, i == 0 && c.HasColumnHeader); err != nil {
+				return nil, err
+			}
+		}
+		data.Children = template.HTML(b.String())
+		return data, nil
+	case *notion.TableRow:
+		for _, cell := range c.Cells {
+			data.Cells = append(data.Cells, r.RenderRichText(cell))
+		}
+	}
+	children, err := r.RenderBlocks(block.Children())
+	if err != nil {
+		return nil, err
+	}
+	data.Children = children
+	return data, nil
+}
+
+func (r *Renderer) pageURL(id, href string) string {
+	if r.PageURL != nil {
+		return r.PageURL(id)
+	}
+	if href != "" {
+		return href
+	}
+	return "https://www.notion.so/" + strings.ReplaceAll(id, "-", "")
+}
+
+// RenderRichText renders rich texts to HTML.
+// Annotations are rendered to <strong>, <em>, <s>, <u> and <code>, and colors to classes of <span>,
+// i.e. "notion-red" for ColorRed and "notion-red_background" for ColorRedBackground.
+// Links with schemes other than http, https, mailto and tel are dropped.
+func (r *Renderer) RenderRichText(texts []*notion.RichText) template.HTML {
+	var b strings.Builder
+	for _, t := range texts {
+		if t != nil {
+			b.WriteString(r.richText(t))
+		}
+	}
+	return template.HTML(b.String())
+}
+
+func (r *Renderer) richText(t *notion.RichText) string {
+	switch t.Type {
+	case notion.RichTextEquation:
+		expression := t.PlainText
+		if t.Equation != nil {
+			expression = t.Equation.Expression
+		}
+		return annotate(`<span class="notion-equation">`+template.HTMLEscapeString(expression)+`</span>`, t.Annotations, "")
+	case notion.RichTextMention:
+		return r.mention(t)
+	default:
+		href := t.Href
+		if t.Text != nil && t.Text.Link != nil {
+			href = t.Text.Link.URL
+		}
+		return annotate(escape(textContent(t)), t.Annotations, href)
+	}
+}
+
+func (r *Renderer) mention(t *notion.RichText) string {
+	m := t.Mention
+	text := escape(t.PlainText)
+	if m == nil {
+		return annotate(text, t.Annotations, t.Href)
+	}
+	var id string
+	switch m.Type {
+	case notion.MentionUser:
+		return annotate(`<span class="notion-mention notion-mention-user">`+text+`</span>`, t.Annotations, "")
+	case notion.MentionDate:
+		if m.Date == nil {
+			return annotate(text, t.Annotations, "")
+		}
+		return annotate(`<time datetime="`+template.HTMLEscapeString(formatDateTime(m.Date.Start))+`">`+text+`</time>`, t.Annotations, "")
+	case notion.MentionPage:
+		if m.Page != nil {
+			id = m.Page.ID
+		}
+	case notion.MentionDatabase:
+		if m.Database != nil {
+			id = m.Database.ID
+		}
+	}
+	class := "notion-mention notion-mention-" + template.HTMLEscapeString(string(m.Type))
+	var href string
+	if id != "" && r.Mentions != MentionInline {
+		href = r.pageURL(id, t.Href)
+	}
+	if href == "" || !safeURL(href) {
+		return annotate(`<span class="`+class+`">`+text+`</span>`, t.Annotations, "")
+	}
+	link := `<a class="` + class + `" href="` + template.HTMLEscapeString(href) + `">` + text + `</a>`
+	return annotate(link, t.Annotations, "")
+}
+
+func formatDateTime(t notion.DateTime) string {
+	if t.DateOnly {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// annotate wraps escaped html with tags of annotations and link.
+func annotate(html string, a notion.Annotation, href string) string {
+	if a.Code {
+		html = "<code>" + html + "</code>"
+	}
+	if a.Underline {
+		html = "<u>" + html + "</u>"
+	}
+	if a.Strikethrough {
+		html = "<s>" + html + "</s>"
+	}
+	if a.Italic {
+		html = "<em>" + html + "</em>"
+	}
+	if a.Bold {
+		html = "<strong>" + html + "</strong>"
+	}
+	if class := colorClass(a.Color); class != "" {
+		html = `<span class="` + class + `">` + html + `</span>`
+	}
+	if href != "" && safeURL(href) {
+		html = `<a href="` + template.HTMLEscapeString(href) + `">` + html + `</a>`
+	}
+	return html
+}
+
+// colorClass returns the class of color, empty for default color.
+func colorClass(c notion.Color) string {
+	if c == "" || c == notion.ColorDefault {
+		return ""
+	}
+	return "notion-" + template.HTMLEscapeString(string(c))
+}
+
+// safeURL reports whether the URL is relative or has a safe scheme.
+func safeURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto", "tel":
+		return true
+	}
+	return false
+}
+
+// escape escapes text and converts line feeds to <br>.
+func escape(s string) string {
+	return strings.ReplaceAll(template.HTMLEscapeString(s), "\n", "<br>")
+}
+
+func textContent(t *notion.RichText) string {
+	if t.Type == notion.RichTextText && t.Text != nil {
+		return t.Text.Content
+	}
+	return t.PlainText
+}
+
+func plainText(texts []*notion.RichText) string {
+	var b strings.Builder
+	for _, t := range texts {
+		if t != nil {
+			b.WriteString(textContent(t))
+		}
+	}
+	return b.String()
+}
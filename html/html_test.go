@@ -0,0 +1,149 @@
+package html
+
+import (
+	"context"
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sorcererxw/go-notion"
+)
+
+// texts builds unstyled rich texts of contents.
+func texts(contents ...string) []*notion.RichText {
+	result := make([]*notion.RichText, 0, len(contents))
+	for _, content := range contents {
+		result = append(result, &notion.RichText{Type: notion.RichTextText, PlainText: content, Text: &notion.Text{Content: content}})
+	}
+	return result
+}
+
+func TestRenderer_RenderRichText(t *testing.T) {
+	styled := &notion.RichText{
+		Type:        notion.RichTextText,
+		PlainText:   "<b>&",
+		Text:        &notion.Text{Content: "<b>&"},
+		Annotations: notion.Annotation{Bold: true, Italic: true, Color: notion.ColorRedBackground},
+	}
+	unsafe := &notion.RichText{
+		Type:      notion.RichTextText,
+		PlainText: "click",
+		Text:      &notion.Text{Content: "click", Link: &notion.Link{URL: "javascript:alert(1)"}},
+	}
+	link := &notion.RichText{
+		Type:      notion.RichTextText,
+		PlainText: "docs",
+		Text:      &notion.Text{Content: "docs", Link: &notion.Link{URL: "https://example.com/?a=1&b=\"2\""}},
+	}
+	mention := &notion.RichText{
+		Type:      notion.RichTextMention,
+		PlainText: "Spec",
+		Href:      "https://www.notion.so/pageid",
+		Mention:   &notion.Mention{Type: notion.MentionPage, Page: &notion.ObjectReference{ID: "page-id"}},
+	}
+	equation := &notion.RichText{Type: notion.RichTextEquation, Equation: &notion.Equation{Expression: "a<b"}}
+	richTexts := append([]*notion.RichText{styled, unsafe, link, mention, equation}, texts("line\nbreak")...)
+
+	r := &Renderer{}
+	assert.Equal(t, template.HTML(
+		`<span class="notion-red_background"><strong><em>&lt;b&gt;&amp;</em></strong></span>`+
+			`click`+
+			`<a href="https://example.com/?a=1&amp;b=&#34;2&#34;">docs</a>`+
+			`<a class="notion-mention notion-mention-page" href="https://www.notion.so/pageid">Spec</a>`+
+			`<span class="notion-equation">a&lt;b</span>`+
+			`line<br>break`,
+	), r.RenderRichText(richTexts))
+
+	r = &Renderer{Mentions: MentionInline}
+	assert.Equal(t, template.HTML(`<span class="notion-mention notion-mention-page">Spec</span>`), r.RenderRichText([]*notion.RichText{mention}))
+	r = &Renderer{PageURL: func(id string) string { return "/help/" + id }}
+	assert.Equal(t, template.HTML(`<a class="notion-mention notion-mention-page" href="/help/page-id">Spec</a>`), r.RenderRichText([]*notion.RichText{mention}))
+
+	r = &Renderer{}
+	unsafeMention := *mention
+	unsafeMention.Href = "javascript:alert(1)"
+	assert.Equal(t, template.HTML(`<span class="notion-mention notion-mention-page">Spec</span>`), r.RenderRichText([]*notion.RichText{&unsafeMention}))
+}
+
+func TestRenderer_RenderBlocks(t *testing.T) {
+	blocks := []*notion.Block{
+		{Type: notion.BlockHeading1, Heading1: &notion.Heading{Text: texts("Title")}},
+		{Type: notion.BlockBulletedListItem, BulletedListItem: &notion.ListItem{
+			Text: texts("a"),
+			Children: []*notion.Block{
+				{Type: notion.BlockNumberedListItem, NumberedListItem: &notion.ListItem{Text: texts("a1")}},
+			},
+		}},
+		{Type: notion.BlockBulletedListItem, BulletedListItem: &notion.ListItem{Text: texts("b")}},
+		{Type: notion.BlockToDo, ToDo: &notion.ToDo{Text: texts("done"), Checked: true}},
+		{Type: notion.BlockCode, Code: &notion.Code{
+			Text:     texts("if a < b {}"),
+			Caption:  texts("cmp"),
+			Language: "go",
+		}},
+		{Type: notion.BlockQuote, Quote: &notion.Quote{Text: texts("q"), Color: notion.ColorGray}},
+		{Type: notion.BlockImage, Image: &notion.FileBlock{Source: notion.NewExternalFile("", "javascript:alert(1)")}},
+		{Type: notion.BlockTable, Table: &notion.Table{TableWidth: 1, HasColumnHeader: true, Children: []*notion.Block{
+			{Type: notion.BlockTableRow, TableRow: &notion.TableRow{Cells: [][]*notion.RichText{texts("h")}}},
+			{Type: notion.BlockTableRow, TableRow: &notion.TableRow{Cells: [][]*notion.RichText{texts("v")}}},
+		}}},
+		{Type: notion.BlockTableOfContents, TableOfContents: &notion.TableOfContents{}},
+		{Type: notion.BlockChildPage, ID: "child-id", ChildPage: &notion.ChildPage{Title: "Child"}},
+	}
+
+	html, err := (&Renderer{}).RenderBlocks(blocks)
+	require.NoError(t, err)
+	assert.Equal(t, template.HTML(
+		`<h1>Title</h1>`+
+			`<ul><li>a<ol><li>a1</li></ol></li><li>b</li></ul>`+
+			`<ul class="notion-to-do-list"><li><input type="checkbox" disabled checked> done</li></ul>`+
+			`<pre><code class="language-go">if a &lt; b {}</code></pre><p class="notion-caption">cmp</p>`+
+			`<blockquote class="notion-gray">q</blockquote>`+
+			`<figure><img src="#ZgotmplZ" alt=""></figure>`+
+			`<table class="notion-table"><tbody><tr><th>h</th></tr><tr><td>v</td></tr></tbody></table>`+
+			`<p class="notion-page"><a href="https://www.notion.so/childid">Child</a></p>`,
+	), html)
+}
+
+func TestRenderer_Templates(t *testing.T) {
+	r := &Renderer{Templates: map[notion.BlockType]*template.Template{
+		notion.BlockDivider:  template.Must(template.New("divider").Parse(`<hr class="fancy">`)),
+		notion.BlockCallout:  template.Must(template.New("callout").Parse(`<aside>{{.Text}}</aside>`)),
+		notion.BlockHeading1: template.Must(template.New("heading").Parse(`{{.Missing}}`)),
+	}}
+	html, err := r.RenderBlocks([]*notion.Block{
+		{Type: notion.BlockDivider, Divider: &notion.Divider{}},
+		{Type: notion.BlockCallout, Callout: &notion.Callout{Text: texts("<tip>")}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, template.HTML(`<hr class="fancy"><aside>&lt;tip&gt;</aside>`), html)
+
+	_, err = r.RenderBlocks([]*notion.Block{{Type: notion.BlockHeading1, Heading1: &notion.Heading{}}})
+	assert.Error(t, err)
+}
+
+// fakeAPI serves block children from a map.
+type fakeAPI struct {
+	notion.API
+	children map[string][]*notion.Block
+}
+
+func (api *fakeAPI) RetrieveBlockChildren(_ context.Context, blockID string, _ int32, _ string) ([]*notion.Block, string, bool, error) {
+	return api.children[blockID], "", false, nil
+}
+
+func TestRenderer_RenderBlockChildren(t *testing.T) {
+	api := &fakeAPI{children: map[string][]*notion.Block{
+		"page": {
+			{ID: "a", Type: notion.BlockToggle, HasChildren: true, Toggle: &notion.Toggle{Text: texts("more")}},
+		},
+		"a": {
+			{ID: "b", Type: notion.BlockParagraph, Paragraph: &notion.Paragraph{Text: texts("hidden")}},
+		},
+	}}
+	html, err := (&Renderer{}).RenderBlockChildren(context.Background(), api, "page")
+	require.NoError(t, err)
+	assert.Equal(t, template.HTML(`<details><summary>more</summary><p>hidden</p></details>`), html)
+}
@@ -0,0 +1,556 @@
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/sorcererxw/go-notion"
+)
+
+// Node is a block being exported.
+type Node struct {
+	Block *notion.Block
+	// Children is the exported Markdown of the children of block.
+	Children string
+	// Number is the 1-based ordinal of numbered list item in its list, 0 for other blocks.
+	Number int
+}
+
+// Hook exports a block to Markdown, and reports whether the block is handled.
+// Blocks not handled are exported by default, empty Markdown means the block is skipped.
+type Hook func(e *Exporter, n *Node) (string, bool)
+
+// Exporter exports Notion blocks to Markdown.
+// The zero value is ready to use.
+type Exporter struct {
+	// Hooks customizes exporting of blocks by type.
+	Hooks map[notion.BlockType]Hook
+	// PageLink returns the link of page or database, which is used by child pages, child databases,
+	// links to page and mentions. Default is "./<id>.md".
+	PageLink func(id, title string) string
+}
+
+// FromBlocks exports blocks to Markdown with the default Exporter.
+func FromBlocks(blocks []*notion.Block) string {
+	return (&Exporter{}).Export(blocks)
+}
+
+// ExportBlockChildren retrieves the block tree under block by notion.RetrieveBlockTree, and exports it to Markdown.
+func (e *Exporter) ExportBlockChildren(ctx context.Context, api notion.API, blockID string) (string, error) {
+	blocks, err := notion.RetrieveBlockTree(ctx, api, blockID)
+	if err != nil {
+		return "", err
+	}
+	return e.Export(blocks), nil
+}
+
+// Export exports blocks and their children to Markdown.
+//
+// Annotations are exported to emphasis, strikethrough, code spans and <u> tags,
+// mentions to links or names, equations to "$...$", to-dos to task list items,
+// toggles to <details> and child pages to links given by PageLink.
+func (e *Exporter) Export(blocks []*notion.Block) string {
+	s := e.blocks(blocks)
+	if s == "" {
+		return ""
+	}
+	return s + "\n"
+}
+
+func (e *Exporter) blocks(blocks []*notion.Block) string {
+	var b strings.Builder
+	var prev *notion.Block
+	number := 0
+	for _, block := range blocks {
+		if block.Type == notion.BlockNumberedListItem {
+			number++
+		} else {
+			number = 0
+		}
+		s := e.block(&Node{Block: block, Children: e.blocks(block.Children()), Number: number})
+		if s == "" {
+			continue
+		}
+		if prev != nil {
+			if listMarker(prev) != "" && listMarker(prev) == listMarker(block) {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(s)
+		prev = block
+	}
+	return b.String()
+}
+
+// listMarker returns the marker of list item block, which decides whether adjacent items are in the same list.
+func listMarker(b *notion.Block) string {
+	switch b.Type {
+	case notion.BlockBulletedListItem, notion.BlockToDo:
+		return "-"
+	case notion.BlockNumberedListItem:
+		return "."
+	}
+	return ""
+}
+
+func (e *Exporter) block(n *Node) string {
+	if hook, ok := e.Hooks[n.Block.Type]; ok {
+		if s, ok := hook(e, n); ok {
+			return s
+		}
+	}
+
+	b := n.Block
+	switch b.Type {
+	case notion.BlockParagraph:
+		if b.Paragraph == nil {
+			return ""
+		}
+		return joinBlocks(escapeLineStart(e.RichText(b.Paragraph.Text)), n.Children)
+	case notion.BlockHeading1:
+		return e.heading("# ", b.Heading1)
+	case notion.BlockHeading2:
+		return e.heading("## ", b.Heading2)
+	case notion.BlockHeading3:
+		return e.heading("### ", b.Heading3)
+	case notion.BlockBulletedListItem:
+		if b.BulletedListItem == nil {
+			return ""
+		}
+		return e.listItem("- ", e.RichText(b.BulletedListItem.Text), n)
+	case notion.BlockNumberedListItem:
+		if b.NumberedListItem == nil {
+			return ""
+		}
+		return e.listItem(fmt.Sprintf("%d. ", n.Number), e.RichText(b.NumberedListItem.Text), n)
+	case notion.BlockToDo:
+		if b.ToDo == nil {
+			return ""
+		}
+		checkbox := "[ ] "
+		if b.ToDo.Checked {
+			checkbox = "[x] "
+		}
+		return e.listItem("- ", checkbox+e.RichText(b.ToDo.Text), n)
+	case notion.BlockToggle:
+		if b.Toggle == nil {
+			return ""
+		}
+		summary := "<details>\n<summary>" + html.EscapeString(plainText(b.Toggle.Text)) + "</summary>"
+		return joinBlocks(summary, n.Children) + "\n\n</details>"
+	case notion.BlockCode:
+		if b.Code == nil {
+			return ""
+		}
+		content := plainText(b.Code.Text)
+		fence := strings.Repeat("`", max(3, longestRun(content, '`')+1))
+		language := b.Code.Language
+		if language == "plain text" {
+			language = ""
+		}
+		return fence + language + "\n" + content + "\n" + fence
+	case notion.BlockQuote:
+		if b.Quote == nil {
+			return ""
+		}
+		return prefixLines(joinBlocks(e.RichText(b.Quote.Text), n.Children), ">")
+	case notion.BlockCallout:
+		if b.Callout == nil {
+			return ""
+		}
+		text := e.RichText(b.Callout.Text)
+		if b.Callout.Icon != nil && b.Callout.Icon.Emoji != "" {
+			text = b.Callout.Icon.Emoji + " " + text
+		}
+		return prefixLines(joinBlocks(text, n.Children), ">")
+	case notion.BlockDivider:
+		return "---"
+	case notion.BlockImage:
+		if b.Image == nil {
+			return ""
+		}
+		return "![" + escape(plainText(b.Image.Caption)) + "](" + linkDestination(b.Image.Source.URL()) + ")"
+	case notion.BlockVideo:
+		return fileLink(b.Video)
+	case notion.BlockFile:
+		return fileLink(b.File)
+	case notion.BlockPDF:
+		return fileLink(b.PDF)
+	case notion.BlockBookmark:
+		if b.Bookmark == nil {
+			return ""
+		}
+		return link(plainText(b.Bookmark.Caption), b.Bookmark.URL)
+	case notion.BlockEmbed:
+		if b.Embed == nil {
+			return ""
+		}
+		return link(plainText(b.Embed.Caption), b.Embed.URL)
+	case notion.BlockEquation:
+		if b.Equation == nil {
+			return ""
+		}
+		return "$$\n" + b.Equation.Expression + "\n$$"
+	case notion.BlockColumnList, notion.BlockColumn, notion.BlockSyncedBlock:
+		return n.Children
+	case notion.BlockTemplate:
+		if b.Template == nil {
+			return ""
+		}
+		return joinBlocks(escapeLineStart(e.RichText(b.Template.Text)), n.Children)
+	case notion.BlockTable:
+		return e.table(b)
+	case notion.BlockChildPage:
+		if b.ChildPage == nil {
+			return ""
+		}
+		return "[" + escape(b.ChildPage.Title) + "](" + linkDestination(e.pageLink(b.ID, b.ChildPage.Title)) + ")"
+	case notion.BlockChildDatabase:
+		if b.ChildDatabase == nil {
+			return ""
+		}
+		return "[" + escape(b.ChildDatabase.Title) + "](" + linkDestination(e.pageLink(b.ID, b.ChildDatabase.Title)) + ")"
+	case notion.BlockLinkToPage:
+		if b.LinkToPage == nil {
+			return ""
+		}
+		id := b.LinkToPage.PageID
+		if id == "" {
+			id = b.LinkToPage.DatabaseID
+		}
+		return "[" + escape(id) + "](" + linkDestination(e.pageLink(id, "")) + ")"
+	default:
+		// Table of contents, breadcrumb and unsupported blocks have no Markdown representation.
+		return ""
+	}
+}
+
+func (e *Exporter) heading(prefix string, h *notion.Heading) string {
+	if h == nil {
+		return ""
+	}
+	return prefix + e.RichText(h.Text)
+}
+
+// listItem exports list item with marker, the children are indented to the content of the item.
+func (e *Exporter) listItem(marker, text string, n *Node) string {
+	s := marker + text
+	if n.Children == "" {
+		return s
+	}
+	separator := "\n\n"
+	if children := n.Block.Children(); len(children) > 0 && listMarker(children[0]) != "" {
+		separator = "\n"
+	}
+	return s + separator + indent(n.Children, len(marker))
+}
+
+func (e *Exporter) table(b *notion.Block) string {
+	if b.Table == nil {
+		return ""
+	}
+	rows := b.Table.Children
+	width := b.Table.TableWidth
+	for _, row := range rows {
+		if row.TableRow != nil && len(row.TableRow.Cells) > width {
+			width = len(row.TableRow.Cells)
+		}
+	}
+	if width == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(rows)+2)
+	if b.Table.HasColumnHeader && len(rows) > 0 {
+		lines = append(lines, e.tableRow(rows[0], width))
+		rows = rows[1:]
+	} else {
+		lines = append(lines, e.tableRow(nil, width))
+	}
+	lines = append(lines, "|"+strings.Repeat(" --- |", width))
+	for _, row := range rows {
+		lines = append(lines, e.tableRow(row, width))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *Exporter) tableRow(row *notion.Block, width int) string {
+	var b strings.Builder
+	b.WriteString("|")
+	for i := 0; i < width; i++ {
+		var cell string
+		if row != nil && row.TableRow != nil && i < len(row.TableRow.Cells) {
+			cell = e.RichText(row.TableRow.Cells[i])
+			// Pipes must be escaped even in code spans of tables.
+			cell = strings.ReplaceAll(strings.ReplaceAll(cell, `\|`, "|"), "|", `\|`)
+			cell = strings.ReplaceAll(strings.ReplaceAll(cell, "\\\n", "<br>"), "\n", "<br>")
+		}
+		b.WriteString(" " + cell + " |")
+	}
+	return b.String()
+}
+
+func (e *Exporter) pageLink(id, title string) string {
+	if e.PageLink != nil {
+		return e.PageLink(id, title)
+	}
+	return "./" + id + ".md"
+}
+
+// RichText exports rich texts to inline Markdown.
+func (e *Exporter) RichText(texts []*notion.RichText) string {
+	var b strings.Builder
+	for _, t := range mergeTexts(texts) {
+		b.WriteString(e.richText(t))
+	}
+	return b.String()
+}
+
+func (e *Exporter) richText(t *notion.RichText) string {
+	switch t.Type {
+	case notion.RichTextEquation:
+		if t.Equation == nil {
+			return escape(t.PlainText)
+		}
+		return "$" + t.Equation.Expression + "$"
+	case notion.RichTextMention:
+		if t.Mention == nil {
+			return escape(t.PlainText)
+		}
+		switch t.Mention.Type {
+		case notion.MentionUser:
+			name := t.PlainText
+			if t.Mention.User != nil && t.Mention.User.Name != "" {
+				name = "@" + t.Mention.User.Name
+			}
+			return annotate(escape(name), t.Annotations, "")
+		case notion.MentionPage:
+			if t.Mention.Page != nil {
+				return annotate(escape(t.PlainText), t.Annotations, e.pageLink(t.Mention.Page.ID, t.PlainText))
+			}
+		case notion.MentionDatabase:
+			if t.Mention.Database != nil {
+				return annotate(escape(t.PlainText), t.Annotations, e.pageLink(t.Mention.Database.ID, t.PlainText))
+			}
+		}
+		return annotate(escape(t.PlainText), t.Annotations, t.Href)
+	default:
+		content := t.PlainText
+		href := t.Href
+		if t.Text != nil {
+			content = t.Text.Content
+			if t.Text.Link != nil {
+				href = t.Text.Link.URL
+			}
+		}
+		if t.Annotations.Code {
+			return annotate(codeSpan(content), t.Annotations, href)
+		}
+		return annotate(strings.ReplaceAll(escape(content), "\n", "\\\n"), t.Annotations, href)
+	}
+}
+
+// mergeTexts merges adjacent texts with the same annotations and link, so that emphasis markers are not split.
+func mergeTexts(texts []*notion.RichText) []*notion.RichText {
+	merged := make([]*notion.RichText, 0, len(texts))
+	for _, t := range texts {
+		if t == nil {
+			continue
+		}
+		if len(merged) > 0 {
+			last := merged[len(merged)-1]
+			if last.Type == notion.RichTextText && t.Type == notion.RichTextText &&
+				last.Annotations == t.Annotations && textLink(last) == textLink(t) {
+				content := textContent(last) + textContent(t)
+				merged[len(merged)-1] = &notion.RichText{
+					Type:        notion.RichTextText,
+					PlainText:   content,
+					Href:        textLink(t),
+					Annotations: t.Annotations,
+					Text:        &notion.Text{Content: content},
+				}
+				continue
+			}
+		}
+		merged = append(merged, t)
+	}
+	return merged
+}
+
+func textContent(t *notion.RichText) string {
+	if t.Text != nil {
+		return t.Text.Content
+	}
+	return t.PlainText
+}
+
+func textLink(t *notion.RichText) string {
+	if t.Text != nil && t.Text.Link != nil {
+		return t.Text.Link.URL
+	}
+	return t.Href
+}
+
+// annotate wraps s with Markdown of annotations and link,
+// the leading and trailing spaces are moved out of emphasis to keep it valid.
+func annotate(s string, a notion.Annotation, href string) string {
+	core := strings.TrimSpace(s)
+	if core == "" {
+		return s
+	}
+	start := strings.Index(s, core)
+	lead, trail := s[:start], s[start+len(core):]
+
+	if a.Underline {
+		core = "<u>" + core + "</u>"
+	}
+	if a.Strikethrough {
+		core = "~~" + core + "~~"
+	}
+	if a.Italic {
+		core = "*" + core + "*"
+	}
+	if a.Bold {
+		core = "**" + core + "**"
+	}
+	if href != "" {
+		core = "[" + core + "](" + linkDestination(href) + ")"
+	}
+	return lead + core + trail
+}
+
+func codeSpan(s string) string {
+	fence := strings.Repeat("`", longestRun(s, '`')+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
+
+func link(text, url string) string {
+	if text == "" {
+		text = url
+	}
+	return "[" + escape(text) + "](" + linkDestination(url) + ")"
+}
+
+func fileLink(f *notion.FileBlock) string {
+	if f == nil {
+		return ""
+	}
+	text := plainText(f.Caption)
+	if text == "" && f.Source != nil {
+		text = f.Source.Name
+	}
+	return link(text, f.Source.URL())
+}
+
+// linkDestination wraps url with angle brackets if it contains characters not allowed in link destination.
+func linkDestination(url string) string {
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+	}
+	return url
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `~`, `\~`, `|`, `\|`, `$`, `\$`,
+)
+
+// escape escapes characters of inline Markdown syntax in s.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// escapeLineStart escapes characters which start a block at the beginning of lines.
+func escapeLineStart(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "#"), strings.HasPrefix(line, "="),
+			strings.HasPrefix(line, "- "), strings.HasPrefix(line, "+ "):
+			lines[i] = `\` + line
+		default:
+			digits := 0
+			for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+				digits++
+			}
+			if digits > 0 && digits < len(line) && (line[digits] == '.' || line[digits] == ')') {
+				lines[i] = line[:digits] + `\` + line[digits:]
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func plainText(texts []*notion.RichText) string {
+	var b strings.Builder
+	for _, t := range texts {
+		if t != nil {
+			b.WriteString(t.PlainText)
+		}
+	}
+	return b.String()
+}
+
+func joinBlocks(text, children string) string {
+	switch {
+	case children == "":
+		return text
+	case text == "":
+		return children
+	}
+	return text + "\n\n" + children
+}
+
+// indent indents non-empty lines of s by n spaces.
+func indent(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = strings.Repeat(" ", n) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prefixLines prefixes lines of s with prefix, and a space for non-empty lines.
+func prefixLines(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = prefix
+		} else {
+			lines[i] = prefix + " " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// longestRun returns the length of the longest run of c in s.
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,142 @@
+package markdown
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sorcererxw/go-notion"
+)
+
+func newTestText(content string) *notion.RichText {
+	return &notion.RichText{Type: notion.RichTextText, PlainText: content, Text: &notion.Text{Content: content}}
+}
+
+func TestFromBlocks_RoundTrip(t *testing.T) {
+	source := "# Release *v1.2*\n" +
+		"\n" +
+		"Some **bold**, ~~removed~~, `code` and [link](https://example.com) with $E=mc^2$.\n" +
+		"\n" +
+		"- item\n" +
+		"  - nested\n" +
+		"- [x] done\n" +
+		"- [ ] todo\n" +
+		"\n" +
+		"1. first\n" +
+		"2. second\n" +
+		"\n" +
+		"> quote\n" +
+		"\n" +
+		"```go\n" +
+		"fmt.Println(\"hi\")\n" +
+		"```\n" +
+		"\n" +
+		"---\n" +
+		"\n" +
+		"$$\n" +
+		"\\sum_i x_i\n" +
+		"$$\n" +
+		"\n" +
+		"| a | b |\n" +
+		"| --- | --- |\n" +
+		"| 1 | 2 |\n"
+
+	blocks, _ := ToBlocks([]byte(source))
+	assert.Equal(t, source, FromBlocks(blocks))
+}
+
+func TestExporter_Export(t *testing.T) {
+	bold := newTestText("bold ")
+	bold.Annotations.Bold = true
+	blocks := []*notion.Block{
+		{Type: notion.BlockParagraph, Paragraph: &notion.Paragraph{Text: []*notion.RichText{
+			bold,
+			newTestText("1 * 2 < 3"),
+			{Type: notion.RichTextMention, PlainText: "Spec", Mention: &notion.Mention{
+				Type: notion.MentionPage,
+				Page: &notion.ObjectReference{ID: "page-id"},
+			}},
+		}}},
+		{Type: notion.BlockNumberedListItem, NumberedListItem: &notion.ListItem{
+			Text: []*notion.RichText{newTestText("one")},
+			Children: []*notion.Block{
+				{Type: notion.BlockParagraph, Paragraph: &notion.Paragraph{Text: []*notion.RichText{newTestText("detail")}}},
+			},
+		}},
+		{Type: notion.BlockNumberedListItem, NumberedListItem: &notion.ListItem{Text: []*notion.RichText{newTestText("two")}}},
+		{Type: notion.BlockToggle, Toggle: &notion.Toggle{
+			Text:     []*notion.RichText{newTestText("More <info>")},
+			Children: []*notion.Block{{Type: notion.BlockParagraph, Paragraph: &notion.Paragraph{Text: []*notion.RichText{newTestText("hidden")}}}},
+		}},
+		{Type: notion.BlockNumberedListItem, NumberedListItem: &notion.ListItem{Text: []*notion.RichText{newTestText("restart")}}},
+		{Type: notion.BlockChildPage, ID: "child-id", ChildPage: &notion.ChildPage{Title: "Child"}},
+		{Type: notion.BlockParagraph, Paragraph: &notion.Paragraph{Text: []*notion.RichText{newTestText("# not heading")}}},
+	}
+
+	expected := "**bold** 1 \\* 2 \\< 3[Spec](./page-id.md)\n" +
+		"\n" +
+		"1. one\n" +
+		"\n" +
+		"   detail\n" +
+		"2. two\n" +
+		"\n" +
+		"<details>\n" +
+		"<summary>More &lt;info&gt;</summary>\n" +
+		"\n" +
+		"hidden\n" +
+		"\n" +
+		"</details>\n" +
+		"\n" +
+		"1. restart\n" +
+		"\n" +
+		"[Child](./child-id.md)\n" +
+		"\n" +
+		"\\# not heading\n"
+	assert.Equal(t, expected, FromBlocks(blocks))
+}
+
+func TestExporter_Hooks(t *testing.T) {
+	e := &Exporter{
+		Hooks: map[notion.BlockType]Hook{
+			notion.BlockCallout: func(e *Exporter, n *Node) (string, bool) {
+				return ":::tip\n" + e.RichText(n.Block.Callout.Text) + "\n:::", true
+			},
+			notion.BlockDivider: func(e *Exporter, n *Node) (string, bool) {
+				return "", false
+			},
+		},
+		PageLink: func(id, title string) string { return "/docs/" + title },
+	}
+	blocks := []*notion.Block{
+		{Type: notion.BlockCallout, Callout: &notion.Callout{Text: []*notion.RichText{newTestText("note")}}},
+		{Type: notion.BlockDivider, Divider: &notion.Divider{}},
+		{Type: notion.BlockChildPage, ID: "id", ChildPage: &notion.ChildPage{Title: "Guide"}},
+	}
+	assert.Equal(t, ":::tip\nnote\n:::\n\n---\n\n[Guide](/docs/Guide)\n", e.Export(blocks))
+}
+
+// fakeAPI serves block children from a map.
+type fakeAPI struct {
+	notion.API
+	children map[string][]*notion.Block
+}
+
+func (api *fakeAPI) RetrieveBlockChildren(_ context.Context, blockID string, _ int32, _ string) ([]*notion.Block, string, bool, error) {
+	return api.children[blockID], "", false, nil
+}
+
+func TestExporter_ExportBlockChildren(t *testing.T) {
+	api := &fakeAPI{children: map[string][]*notion.Block{
+		"page": {
+			{ID: "a", Type: notion.BlockBulletedListItem, HasChildren: true, BulletedListItem: &notion.ListItem{Text: []*notion.RichText{newTestText("a")}}},
+		},
+		"a": {
+			{ID: "b", Type: notion.BlockToDo, ToDo: &notion.ToDo{Text: []*notion.RichText{newTestText("b")}}},
+		},
+	}}
+	s, err := (&Exporter{}).ExportBlockChildren(context.Background(), api, "page")
+	require.NoError(t, err)
+	assert.Equal(t, "- a\n  - [ ] b\n", s)
+}
@@ -0,0 +1,536 @@
+// Package markdown converts between Markdown documents and Notion blocks.
+package markdown
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+
+	"github.com/sorcererxw/go-notion"
+)
+
+// maxTextLength is the max length of the content of a rich text accepted by Notion.
+const maxTextLength = 2000
+
+// Degradation reports a Markdown construct which can't be represented by Notion blocks exactly.
+type Degradation struct {
+	// Line is the 1-based line number of the construct, 0 if unknown.
+	Line int
+	// Kind is the kind of the Markdown node, i.e. "Heading", "RawHTML".
+	Kind string
+	// Reason describes how the construct is degraded.
+	Reason string
+}
+
+// String implements fmt.Stringer.
+func (d Degradation) String() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.Kind, d.Reason)
+	}
+	return fmt.Sprintf("line %d: %s: %s", d.Line, d.Kind, d.Reason)
+}
+
+var md = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithInlineParsers(util.Prioritized(&mathParser{}, 500))),
+)
+
+// ToBlocks converts a CommonMark document with GFM extensions to Notion blocks,
+// which can be passed to API.AppendBlockChildren.
+//
+// Emphasis, strong emphasis, strikethrough and code spans are converted to annotations,
+// absolute links to Text.Link, list items, task list items, headings, fenced code blocks,
+// block quotes, thematic breaks and tables to the corresponding blocks,
+// and LaTeX delimited by "$" or "$$" to equations.
+// Constructs which can't be represented exactly are degraded and reported.
+func ToBlocks(source []byte) ([]*notion.Block, []Degradation) {
+	c := &converter{source: source}
+	for i, b := range source {
+		if b == '\n' {
+			c.lines = append(c.lines, i)
+		}
+	}
+	doc := md.Parser().Parse(text.NewReader(source))
+	return c.blocks(doc), c.degradations
+}
+
+// style is the inline style inherited from ancestor nodes.
+type style struct {
+	annotations notion.Annotation
+	link        string
+}
+
+type converter struct {
+	source []byte
+	// lines is the offsets of line feeds.
+	lines        []int
+	degradations []Degradation
+}
+
+func (c *converter) degrade(n ast.Node, format string, args ...interface{}) {
+	c.degradations = append(c.degradations, Degradation{
+		Line:   c.line(n),
+		Kind:   n.Kind().String(),
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// line returns the 1-based line number of node, 0 if unknown.
+func (c *converter) line(n ast.Node) int {
+	offset := -1
+	switch v := n.(type) {
+	case *ast.Text:
+		offset = v.Segment.Start
+	case *ast.RawHTML:
+		if v.Segments.Len() > 0 {
+			offset = v.Segments.At(0).Start
+		}
+	case *ast.FencedCodeBlock:
+		// Lines of fenced code block don't include the opening fence.
+		if v.Info != nil {
+			offset = v.Info.Segment.Start
+		} else if v.Lines().Len() > 0 {
+			offset = v.Lines().At(0).Start
+		}
+	default:
+		if n.Type() == ast.TypeBlock && n.Lines().Len() > 0 {
+			offset = n.Lines().At(0).Start
+		}
+	}
+	if offset < 0 {
+		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+			if l := c.line(child); l > 0 {
+				return l
+			}
+		}
+		return 0
+	}
+	return sort.SearchInts(c.lines, offset) + 1
+}
+
+// blocks converts children of n to blocks.
+func (c *converter) blocks(n ast.Node) []*notion.Block {
+	var blocks []*notion.Block
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		blocks = append(blocks, c.block(child)...)
+	}
+	return blocks
+}
+
+func (c *converter) block(n ast.Node) []*notion.Block {
+	switch n := n.(type) {
+	case *ast.Paragraph, *ast.TextBlock:
+		return []*notion.Block{c.paragraph(n)}
+	case *ast.Heading:
+		heading := &notion.Heading{Text: c.richTexts(n)}
+		switch n.Level {
+		case 1:
+			return []*notion.Block{{Object: notion.ObjectBlock, Type: notion.BlockHeading1, Heading1: heading}}
+		case 2:
+			return []*notion.Block{{Object: notion.ObjectBlock, Type: notion.BlockHeading2, Heading2: heading}}
+		default:
+			if n.Level > 3 {
+				c.degrade(n, "heading level %d is converted to heading_3", n.Level)
+			}
+			return []*notion.Block{{Object: notion.ObjectBlock, Type: notion.BlockHeading3, Heading3: heading}}
+		}
+	case *ast.ThematicBreak:
+		return []*notion.Block{{Object: notion.ObjectBlock, Type: notion.BlockDivider, Divider: &notion.Divider{}}}
+	case *ast.FencedCodeBlock:
+		return []*notion.Block{c.code(n, string(n.Language(c.source)))}
+	case *ast.CodeBlock:
+		return []*notion.Block{c.code(n, "")}
+	case *ast.Blockquote:
+		text, children := c.splitFirstParagraph(n)
+		return []*notion.Block{{
+			Object: notion.ObjectBlock,
+			Type:   notion.BlockQuote,
+			Quote:  &notion.Quote{Text: text, Children: children},
+		}}
+	case *ast.List:
+		if n.IsOrdered() && n.Start != 1 {
+			c.degrade(n, "numbered list starting at %d is renumbered from 1", n.Start)
+		}
+		var blocks []*notion.Block
+		for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+			blocks = append(blocks, c.listItem(item, n.IsOrdered()))
+		}
+		return blocks
+	case *east.Table:
+		return []*notion.Block{c.table(n)}
+	case *ast.HTMLBlock:
+		c.degrade(n, "HTML block is converted to plain text")
+		var b strings.Builder
+		for i := 0; i < n.Lines().Len(); i++ {
+			line := n.Lines().At(i)
+			b.Write(line.Value(c.source))
+		}
+		if n.HasClosure() {
+			b.Write(n.ClosureLine.Value(c.source))
+		}
+		content := strings.TrimRight(b.String(), "\n")
+		return []*notion.Block{{
+			Object:    notion.ObjectBlock,
+			Type:      notion.BlockParagraph,
+			Paragraph: &notion.Paragraph{Text: compact([]*notion.RichText{newText(content, style{})})},
+		}}
+	default:
+		c.degrade(n, "unsupported block is skipped")
+		return nil
+	}
+}
+
+// paragraph converts a paragraph to a paragraph block,
+// or an image or equation block if the paragraph contains only a single image or display math.
+func (c *converter) paragraph(n ast.Node) *notion.Block {
+	// Display math is parsed from lines, since mathParser only parses within a line.
+	var b strings.Builder
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		b.Write(line.Value(c.source))
+	}
+	if s := strings.TrimSpace(b.String()); len(s) > 4 && strings.HasPrefix(s, "$$") && strings.HasSuffix(s, "$$") &&
+		!strings.Contains(s[2:len(s)-2], "$$") {
+		return &notion.Block{
+			Object:   notion.ObjectBlock,
+			Type:     notion.BlockEquation,
+			Equation: &notion.Equation{Expression: strings.TrimSpace(s[2 : len(s)-2])},
+		}
+	}
+	if image, ok := n.FirstChild().(*ast.Image); ok && image.NextSibling() == nil && isAbsoluteURL(string(image.Destination)) {
+		return &notion.Block{
+			Object: notion.ObjectBlock,
+			Type:   notion.BlockImage,
+			Image: &notion.FileBlock{
+				Source:  notion.NewExternalFile("", string(image.Destination)),
+				Caption: c.richTexts(image),
+			},
+		}
+	}
+	return &notion.Block{
+		Object:    notion.ObjectBlock,
+		Type:      notion.BlockParagraph,
+		Paragraph: &notion.Paragraph{Text: c.richTexts(n)},
+	}
+}
+
+// splitFirstParagraph converts the first paragraph of n to rich texts, and the rest children to blocks.
+func (c *converter) splitFirstParagraph(n ast.Node) ([]*notion.RichText, []*notion.Block) {
+	first := n.FirstChild()
+	if first == nil {
+		return []*notion.RichText{}, nil
+	}
+	if first.Kind() != ast.KindParagraph && first.Kind() != ast.KindTextBlock {
+		return []*notion.RichText{}, c.blocks(n)
+	}
+	var children []*notion.Block
+	for child := first.NextSibling(); child != nil; child = child.NextSibling() {
+		children = append(children, c.block(child)...)
+	}
+	return c.richTexts(first), children
+}
+
+func (c *converter) listItem(n ast.Node, ordered bool) *notion.Block {
+	var checkbox *east.TaskCheckBox
+	if first := n.FirstChild(); first != nil && first.FirstChild() != nil {
+		checkbox, _ = first.FirstChild().(*east.TaskCheckBox)
+	}
+	text, children := c.splitFirstParagraph(n)
+	switch {
+	case checkbox != nil:
+		return &notion.Block{
+			Object: notion.ObjectBlock,
+			Type:   notion.BlockToDo,
+			ToDo:   &notion.ToDo{Text: text, Children: children, Checked: checkbox.IsChecked},
+		}
+	case ordered:
+		return &notion.Block{
+			Object:           notion.ObjectBlock,
+			Type:             notion.BlockNumberedListItem,
+			NumberedListItem: &notion.ListItem{Text: text, Children: children},
+		}
+	default:
+		return &notion.Block{
+			Object:           notion.ObjectBlock,
+			Type:             notion.BlockBulletedListItem,
+			BulletedListItem: &notion.ListItem{Text: text, Children: children},
+		}
+	}
+}
+
+// codeLanguages maps common aliases of Markdown info strings to languages of Notion.
+var codeLanguages = map[string]string{
+	"sh":         "shell",
+	"zsh":        "shell",
+	"js":         "javascript",
+	"jsx":        "javascript",
+	"ts":         "typescript",
+	"tsx":        "typescript",
+	"py":         "python",
+	"rb":         "ruby",
+	"rs":         "rust",
+	"kt":         "kotlin",
+	"golang":     "go",
+	"cpp":        "c++",
+	"cc":         "c++",
+	"cs":         "c#",
+	"csharp":     "c#",
+	"fsharp":     "f#",
+	"objc":       "objective-c",
+	"yml":        "yaml",
+	"md":         "markdown",
+	"tex":        "latex",
+	"dockerfile": "docker",
+	"make":       "makefile",
+	"proto":      "protobuf",
+	"ps1":        "powershell",
+	"pwsh":       "powershell",
+	"hs":         "haskell",
+	"ex":         "elixir",
+	"exs":        "elixir",
+	"htm":        "html",
+	"text":       "plain text",
+	"txt":        "plain text",
+	"plaintext":  "plain text",
+}
+
+// notionLanguages is the languages of code block supported by Notion.
+var notionLanguages = map[string]bool{}
+
+func init() {
+	for _, l := range []string{
+		"abap", "arduino", "bash", "basic", "c", "clojure", "coffeescript", "c++", "c#", "css", "dart",
+		"diff", "docker", "elixir", "elm", "erlang", "flow", "fortran", "f#", "gherkin", "glsl", "go",
+		"graphql", "groovy", "haskell", "html", "java", "javascript", "json", "julia", "kotlin", "latex",
+		"less", "lisp", "livescript", "lua", "makefile", "markdown", "markup", "matlab", "mermaid", "nix",
+		"objective-c", "ocaml", "pascal", "perl", "php", "plain text", "powershell", "prolog", "protobuf",
+		"python", "r", "reason", "ruby", "rust", "sass", "scala", "scheme", "scss", "shell", "sql", "swift",
+		"typescript", "vb.net", "verilog", "vhdl", "visual basic", "webassembly", "xml", "yaml",
+	} {
+		notionLanguages[l] = true
+	}
+}
+
+func (c *converter) code(n ast.Node, language string) *notion.Block {
+	language = strings.ToLower(language)
+	if alias, ok := codeLanguages[language]; ok {
+		language = alias
+	}
+	if language == "" {
+		language = "plain text"
+	} else if !notionLanguages[language] {
+		c.degrade(n, "code language %q is not supported, converted to plain text", language)
+		language = "plain text"
+	}
+	var b strings.Builder
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		b.Write(line.Value(c.source))
+	}
+	content := strings.TrimSuffix(b.String(), "\n")
+	return &notion.Block{
+		Object: notion.ObjectBlock,
+		Type:   notion.BlockCode,
+		Code: &notion.Code{
+			Text:     compact([]*notion.RichText{newText(content, style{})}),
+			Language: language,
+		},
+	}
+}
+
+func (c *converter) table(n *east.Table) *notion.Block {
+	for _, a := range n.Alignments {
+		if a != east.AlignNone {
+			c.degrade(n, "column alignment is dropped")
+			break
+		}
+	}
+	table := &notion.Table{TableWidth: len(n.Alignments)}
+	for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+		if row.Kind() == east.KindTableHeader {
+			table.HasColumnHeader = true
+		}
+		cells := make([][]*notion.RichText, 0, table.TableWidth)
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, c.richTexts(cell))
+		}
+		table.Children = append(table.Children, &notion.Block{
+			Object:   notion.ObjectBlock,
+			Type:     notion.BlockTableRow,
+			TableRow: &notion.TableRow{Cells: cells},
+		})
+	}
+	return &notion.Block{Object: notion.ObjectBlock, Type: notion.BlockTable, Table: table}
+}
+
+// richTexts converts inline children of n to rich texts.
+func (c *converter) richTexts(n ast.Node) []*notion.RichText {
+	return compact(c.inlines(n, style{}))
+}
+
+func (c *converter) inlines(n ast.Node, s style) []*notion.RichText {
+	var texts []*notion.RichText
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		texts = append(texts, c.inline(child, s)...)
+	}
+	return texts
+}
+
+func (c *converter) inline(n ast.Node, s style) []*notion.RichText {
+	switch n := n.(type) {
+	case *ast.Text:
+		value := n.Segment.Value(c.source)
+		if !n.IsRaw() {
+			value = util.UnescapePunctuations(value)
+			value = util.ResolveNumericReferences(value)
+			value = util.ResolveEntityNames(value)
+		}
+		content := string(value)
+		switch {
+		case n.HardLineBreak():
+			content += "\n"
+		case n.SoftLineBreak():
+			content += " "
+		}
+		return []*notion.RichText{newText(content, s)}
+	case *ast.String:
+		return []*notion.RichText{newText(string(n.Value), s)}
+	case *ast.CodeSpan:
+		var b strings.Builder
+		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+			if t, ok := child.(*ast.Text); ok {
+				b.Write(t.Segment.Value(c.source))
+				if t.SoftLineBreak() {
+					b.WriteByte(' ')
+				}
+			}
+		}
+		s.annotations.Code = true
+		return []*notion.RichText{newText(b.String(), s)}
+	case *ast.Emphasis:
+		if n.Level >= 2 {
+			s.annotations.Bold = true
+		} else {
+			s.annotations.Italic = true
+		}
+		return c.inlines(n, s)
+	case *east.Strikethrough:
+		s.annotations.Strikethrough = true
+		return c.inlines(n, s)
+	case *ast.Link:
+		if dest := string(n.Destination); isAbsoluteURL(dest) {
+			s.link = dest
+		} else {
+			c.degrade(n, "relative link %q is dropped", dest)
+		}
+		return c.inlines(n, s)
+	case *ast.AutoLink:
+		s.link = string(n.URL(c.source))
+		return []*notion.RichText{newText(string(n.Label(c.source)), s)}
+	case *ast.Image:
+		c.degrade(n, "inline image is converted to its alternative text")
+		if dest := string(n.Destination); s.link == "" && isAbsoluteURL(dest) {
+			s.link = dest
+		}
+		return c.inlines(n, s)
+	case *ast.RawHTML:
+		c.degrade(n, "raw HTML is converted to plain text")
+		var b strings.Builder
+		for i := 0; i < n.Segments.Len(); i++ {
+			segment := n.Segments.At(i)
+			b.Write(segment.Value(c.source))
+		}
+		return []*notion.RichText{newText(b.String(), s)}
+	case *mathNode:
+		return []*notion.RichText{{
+			Type:        notion.RichTextEquation,
+			PlainText:   string(n.expression),
+			Annotations: s.annotations,
+			Equation:    &notion.Equation{Expression: string(n.expression)},
+		}}
+	case *east.TaskCheckBox:
+		// Task check box is converted by listItem.
+		return nil
+	default:
+		c.degrade(n, "unsupported inline is converted to its text")
+		return []*notion.RichText{newText(string(n.Text(c.source)), s)}
+	}
+}
+
+func newText(content string, s style) *notion.RichText {
+	text := &notion.RichText{
+		Type:        notion.RichTextText,
+		PlainText:   content,
+		Annotations: s.annotations,
+		Text:        &notion.Text{Content: content},
+	}
+	if s.link != "" {
+		text.Href = s.link
+		text.Text.Link = &notion.Link{Type: "url", URL: s.link}
+	}
+	return text
+}
+
+// compact merges adjacent texts with the same style, and splits texts exceeding the max length of Notion.
+func compact(texts []*notion.RichText) []*notion.RichText {
+	merged := make([]*notion.RichText, 0, len(texts))
+	for _, t := range texts {
+		if len(merged) > 0 {
+			last := merged[len(merged)-1]
+			if last.Type == notion.RichTextText && t.Type == notion.RichTextText &&
+				last.Annotations == t.Annotations && last.Href == t.Href {
+				last.Text.Content += t.Text.Content
+				last.PlainText = last.Text.Content
+				continue
+			}
+		}
+		merged = append(merged, t)
+	}
+
+	results := make([]*notion.RichText, 0, len(merged))
+	for _, t := range merged {
+		if t.Type != notion.RichTextText {
+			results = append(results, t)
+			continue
+		}
+		for _, content := range splitText(t.Text.Content) {
+			s := style{annotations: t.Annotations, link: t.Href}
+			results = append(results, newText(content, s))
+		}
+	}
+	return results
+}
+
+// splitText splits s into chunks of at most maxTextLength characters.
+func splitText(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var chunks []string
+	for utf8.RuneCountInString(s) > maxTextLength {
+		i, n := 0, 0
+		for n < maxTextLength {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+			n++
+		}
+		chunks = append(chunks, s[:i])
+		s = s[i:]
+	}
+	return append(chunks, s)
+}
+
+func isAbsoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs()
+}
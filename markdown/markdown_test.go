@@ -0,0 +1,135 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sorcererxw/go-notion"
+)
+
+func plainTexts(texts []*notion.RichText) string {
+	var b strings.Builder
+	for _, t := range texts {
+		b.WriteString(t.PlainText)
+	}
+	return b.String()
+}
+
+func TestToBlocks(t *testing.T) {
+	source := "# Release *v1.2*\n" +
+		"\n" +
+		"Some **bold**, ~~removed~~, `code` and [link](https://example.com) with $E=mc^2$.\n" +
+		"\n" +
+		"- item\n" +
+		"  - nested\n" +
+		"- [x] done\n" +
+		"- [ ] todo\n" +
+		"\n" +
+		"1. first\n" +
+		"2. second\n" +
+		"\n" +
+		"> quote\n" +
+		"\n" +
+		"```go\n" +
+		"fmt.Println(\"hi\")\n" +
+		"```\n" +
+		"\n" +
+		"---\n" +
+		"\n" +
+		"$$\\sum_i x_i$$\n" +
+		"\n" +
+		"| a | b |\n" +
+		"|---|---|\n" +
+		"| 1 | 2 |\n"
+
+	blocks, degradations := ToBlocks([]byte(source))
+	assert.Empty(t, degradations)
+	require.Len(t, blocks, 12)
+
+	assert.Equal(t, notion.BlockHeading1, blocks[0].Type)
+	require.Len(t, blocks[0].Heading1.Text, 2)
+	assert.Equal(t, "Release ", blocks[0].Heading1.Text[0].PlainText)
+	assert.True(t, blocks[0].Heading1.Text[1].Annotations.Italic)
+
+	texts := blocks[1].Paragraph.Text
+	assert.Equal(t, "Some bold, removed, code and link with E=mc^2.", plainTexts(texts))
+	assert.True(t, texts[1].Annotations.Bold)
+	assert.True(t, texts[3].Annotations.Strikethrough)
+	assert.True(t, texts[5].Annotations.Code)
+	assert.Equal(t, "https://example.com", texts[7].Text.Link.URL)
+	assert.Equal(t, notion.RichTextEquation, texts[9].Type)
+	assert.Equal(t, "E=mc^2", texts[9].Equation.Expression)
+
+	assert.Equal(t, notion.BlockBulletedListItem, blocks[2].Type)
+	assert.Equal(t, "item", plainTexts(blocks[2].BulletedListItem.Text))
+	require.Len(t, blocks[2].BulletedListItem.Children, 1)
+	assert.Equal(t, "nested", plainTexts(blocks[2].BulletedListItem.Children[0].BulletedListItem.Text))
+	assert.Equal(t, notion.BlockToDo, blocks[3].Type)
+	assert.True(t, blocks[3].ToDo.Checked)
+	assert.Equal(t, "done", plainTexts(blocks[3].ToDo.Text))
+	assert.False(t, blocks[4].ToDo.Checked)
+
+	assert.Equal(t, notion.BlockNumberedListItem, blocks[5].Type)
+	assert.Equal(t, notion.BlockNumberedListItem, blocks[6].Type)
+	assert.Equal(t, "quote", plainTexts(blocks[7].Quote.Text))
+	assert.Equal(t, "go", blocks[8].Code.Language)
+	assert.Equal(t, "fmt.Println(\"hi\")", plainTexts(blocks[8].Code.Text))
+	assert.Equal(t, notion.BlockDivider, blocks[9].Type)
+	assert.Equal(t, `\sum_i x_i`, blocks[10].Equation.Expression)
+
+	table := blocks[11].Table
+	assert.Equal(t, 2, table.TableWidth)
+	assert.True(t, table.HasColumnHeader)
+	require.Len(t, table.Children, 2)
+	assert.Equal(t, "2", plainTexts(table.Children[1].TableRow.Cells[1]))
+}
+
+func TestToBlocks_Degradations(t *testing.T) {
+	source := "#### Deep\n" +
+		"\n" +
+		"See [docs](./docs.md) and <b>html</b>.\n" +
+		"\n" +
+		"```brainfuck\n" +
+		"+++\n" +
+		"```\n"
+
+	blocks, degradations := ToBlocks([]byte(source))
+	require.Len(t, blocks, 3)
+	assert.Equal(t, notion.BlockHeading3, blocks[0].Type)
+	assert.Equal(t, "See docs and <b>html</b>.", plainTexts(blocks[1].Paragraph.Text))
+	assert.Nil(t, blocks[1].Paragraph.Text[0].Text.Link)
+	assert.Equal(t, "plain text", blocks[2].Code.Language)
+
+	var lines []int
+	var kinds []string
+	for _, d := range degradations {
+		lines = append(lines, d.Line)
+		kinds = append(kinds, d.Kind)
+	}
+	assert.Equal(t, []int{1, 3, 3, 3, 5}, lines)
+	assert.Equal(t, []string{"Heading", "Link", "RawHTML", "RawHTML", "FencedCodeBlock"}, kinds)
+	assert.Equal(t, "line 1: Heading: heading level 4 is converted to heading_3", degradations[0].String())
+}
+
+func TestToBlocks_LongText(t *testing.T) {
+	blocks, _ := ToBlocks([]byte(strings.Repeat("é", 4500)))
+	require.Len(t, blocks, 1)
+	texts := blocks[0].Paragraph.Text
+	require.Len(t, texts, 3)
+	assert.Equal(t, 2000, len([]rune(texts[0].Text.Content)))
+	assert.Equal(t, 500, len([]rune(texts[2].Text.Content)))
+}
+
+func TestToBlocks_Image(t *testing.T) {
+	blocks, degradations := ToBlocks([]byte("![A *cat*](https://example.com/cat.png)\n\nSee ![icon](./icon.png)\n"))
+	require.Len(t, blocks, 2)
+	assert.Equal(t, notion.BlockImage, blocks[0].Type)
+	assert.Equal(t, "https://example.com/cat.png", blocks[0].Image.Source.URL())
+	assert.Equal(t, "A cat", plainTexts(blocks[0].Image.Caption))
+	assert.Equal(t, "See icon", plainTexts(blocks[1].Paragraph.Text))
+	require.Len(t, degradations, 1)
+	assert.Equal(t, "Image", degradations[0].Kind)
+}
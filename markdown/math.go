@@ -0,0 +1,70 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// kindMath is the NodeKind of mathNode.
+var kindMath = ast.NewNodeKind("Math")
+
+// mathNode is inline LaTeX, i.e. "$E = mc^2$" or "$$E = mc^2$$".
+type mathNode struct {
+	ast.BaseInline
+	expression []byte
+}
+
+// Kind implements ast.Node.Kind.
+func (n *mathNode) Kind() ast.NodeKind { return kindMath }
+
+// Dump implements ast.Node.Dump.
+func (n *mathNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Expression": string(n.expression)}, nil)
+}
+
+// mathParser parses inline LaTeX delimited by "$" or "$$" within a single line.
+type mathParser struct{}
+
+var _ parser.InlineParser = &mathParser{}
+
+// Trigger implements parser.InlineParser.Trigger.
+func (p *mathParser) Trigger() []byte { return []byte{'$'} }
+
+// Parse implements parser.InlineParser.Parse.
+func (p *mathParser) Parse(_ ast.Node, block text.Reader, _ parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+	delim := 1
+	if len(line) > 1 && line[1] == '$' {
+		delim = 2
+	}
+	rest := line[delim:]
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == '\\' {
+			i++
+			continue
+		}
+		if rest[i] != '$' {
+			continue
+		}
+		if delim == 2 && (i+1 >= len(rest) || rest[i+1] != '$') {
+			continue
+		}
+		expression := rest[:i]
+		if len(expression) == 0 {
+			return nil
+		}
+		// Follow the convention of pandoc to avoid treating prices like "$5 and $6" as math.
+		if delim == 1 && (isSpace(expression[0]) || isSpace(expression[len(expression)-1]) ||
+			i+1 < len(rest) && rest[i+1] >= '0' && rest[i+1] <= '9') {
+			return nil
+		}
+		block.Advance(delim + i + delim)
+		return &mathNode{expression: append([]byte(nil), expression...)}
+	}
+	return nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
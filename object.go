@@ -14,6 +14,9 @@ const (
 	ObjectPage     ObjectType = "page"
 	ObjectUser     ObjectType = "user"
 	ObjectBlock    ObjectType = "block"
+	ObjectComment  ObjectType = "comment"
+	// ObjectPropertyItem is the item of paginated page property value.
+	ObjectPropertyItem ObjectType = "property_item"
 )
 
 // Object is the mix type of Top-level resources.
@@ -24,6 +27,7 @@ type Object struct {
 	page     *Page
 	user     *User
 	block    *Block
+	comment  *Comment
 }
 
 // List cast Object to List
@@ -66,6 +70,14 @@ func (o *Object) Block() *Block {
 	return o.block
 }
 
+// Comment casts Object to Comment
+func (o *Object) Comment() *Comment {
+	if o == nil {
+		return nil
+	}
+	return o.comment
+}
+
 func (o *Object) value() (interface{}, bool) {
 	if o == nil {
 		return nil, false
@@ -86,6 +98,9 @@ func (o *Object) value() (interface{}, bool) {
 	case ObjectUser:
 		o.user = new(User)
 		return o.user, true
+	case ObjectComment:
+		o.comment = new(Comment)
+		return o.comment, true
 	}
 	return nil, false
 }
@@ -172,3 +187,14 @@ func (os Objects) Pages() []*Page {
 	}
 	return dest
 }
+
+// Comments extracts valid comments from objects.
+func (os Objects) Comments() []*Comment {
+	dest := make([]*Comment, 0, len(os))
+	for _, o := range os {
+		if v := o.Comment(); v != nil {
+			dest = append(dest, v)
+		}
+	}
+	return dest
+}
@@ -18,6 +18,10 @@ type Page struct {
 	Archived       bool                     `json:"archived,omitempty"`
 	Properties     map[string]PropertyValue `json:"properties,omitempty"`
 	Parent         Parent                   `json:"parent,omitempty"`
+	Icon           *Icon                    `json:"icon,omitempty"`
+	Cover          *Cover                   `json:"cover,omitempty"`
+	// URL is the link to the page in Notion.
+	URL string `json:"url,omitempty"`
 }
 
 // MarshalJSON marshal Page to json and set Object to "page" automatically.
@@ -30,7 +34,26 @@ func (p *Page) MarshalJSON() ([]byte, error) {
 	return json.Marshal((*Alias)(p))
 }
 
-var _ json.Marshaler = &Page{}
+// UnmarshalJSON unmarshal Page from json, and binds files in properties to the page for refreshing.
+func (p *Page) UnmarshalJSON(bytes []byte) error {
+	type Alias Page
+	if err := json.Unmarshal(bytes, (*Alias)(p)); err != nil {
+		return err
+	}
+	for _, v := range p.Properties {
+		for i, f := range v.Files {
+			if f != nil {
+				f.owner = fileOwner{pageID: p.ID, propertyID: v.ID, index: i}
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	_ json.Marshaler   = &Page{}
+	_ json.Unmarshaler = &Page{}
+)
 
 // ParentType is type of Parent.
 type ParentType string
@@ -40,6 +63,8 @@ const (
 	ParentDatabase  ParentType = "database_id"
 	ParentPage      ParentType = "page_id"
 	ParentWorkspace ParentType = "workspace"
+	// ParentBlock is only available for comments.
+	ParentBlock ParentType = "block_id"
 )
 
 // Parent represents the Page parent.
@@ -47,6 +72,7 @@ type Parent struct {
 	Type       ParentType `json:"type,omitempty"`
 	PageID     string     `json:"page_id,omitempty"`
 	DatabaseID string     `json:"database_id,omitempty"`
+	BlockID    string     `json:"block_id,omitempty"`
 	Workspace  bool       `json:"workspace,omitempty"`
 }
 
@@ -92,6 +118,7 @@ type RollupValueType string
 // RollupValueType enums.
 const (
 	RollupValueString RollupValueType = "string"
+	RollupValueNumber RollupValueType = "number"
 	RolluoValueDate   RollupValueType = "date"
 	RolluoValueArray  RollupValueType = "array"
 )
@@ -100,9 +127,10 @@ const (
 // These objects contain a type key and a key corresponding with the value of type.
 // The value is an object containing type-specific data.
 type RollupValue struct {
-	Type   RollupValueType `json:"type,omitempty"`
-	Number float64         `json:"number,omitempty"`
-	Date   *Date           `json:"date,omitempty"`
+	Type     RollupValueType `json:"type,omitempty"`
+	Function RollupFunction  `json:"function,omitempty"`
+	Number   float64         `json:"number,omitempty"`
+	Date     *Date           `json:"date,omitempty"`
 	// The element is exactly like property value object, but without the "id" key.
 	Array []*PropertyValue `json:"array,omitempty"`
 }
@@ -122,6 +150,8 @@ type PropertyValue struct {
 	// Relation is an array of page references.
 	Relation []*ObjectReference `json:"relation,omitempty"`
 	Rollup   *RollupValue       `json:"rollup,omitempty"`
+	// HasMore reports whether the value is truncated, use API.RetrievePageProperty to retrieve the complete value.
+	HasMore bool `json:"has_more,omitempty"`
 	// People is an array of user objects.
 	People []*User `json:"people,omitempty"`
 	// Files is an array of file references.
@@ -141,6 +171,134 @@ type PropertyValue struct {
 	CreatedTime *time.Time `json:"created_time,omitempty"`
 	// LastEditedTime contains the date and time when this page was last updated.
 	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+
+	// null reports whether the value of Type is null, which is only meaningful for number.
+	null bool
+}
+
+// MarshalJSON always encodes the value named by Type, so that zero values like 0 and false,
+// and empty values like null select and empty relations are sent to clear the property.
+func (v PropertyValue) MarshalJSON() ([]byte, error) {
+	type Alias PropertyValue
+	value, ok := v.typedValue()
+	if !ok {
+		return json.Marshal(Alias(v))
+	}
+	m := map[string]interface{}{
+		"type":         v.Type,
+		string(v.Type): value,
+	}
+	if v.ID != "" {
+		m["id"] = v.ID
+	}
+	if v.HasMore {
+		m["has_more"] = v.HasMore
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes PropertyValue and remembers null number.
+func (v *PropertyValue) UnmarshalJSON(b []byte) error {
+	type Alias PropertyValue
+	if err := json.Unmarshal(b, (*Alias)(v)); err != nil {
+		return err
+	}
+	v.null = false
+	if v.Type == PropertyNumber {
+		var raw struct {
+			Number json.RawMessage `json:"number"`
+		}
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return err
+		}
+		v.null = raw.Number == nil || string(raw.Number) == "null"
+	}
+	return nil
+}
+
+var (
+	_ json.Marshaler   = PropertyValue{}
+	_ json.Unmarshaler = &PropertyValue{}
+)
+
+// typedValue returns the value named by Type, empty values are converted to JSON null or empty array.
+func (v PropertyValue) typedValue() (interface{}, bool) {
+	switch v.Type {
+	case PropertyTitle:
+		return nonNilRichTexts(v.Title), true
+	case PropertyRichText:
+		return nonNilRichTexts(v.RichText), true
+	case PropertyNumber:
+		if v.null {
+			return nil, true
+		}
+		return v.Number, true
+	case PropertySelect:
+		return v.Select, true
+	case PropertyMultiSelect:
+		if v.MultiSelect == nil {
+			return []*SelectOption{}, true
+		}
+		return v.MultiSelect, true
+	case PropertyDate:
+		return v.Date, true
+	case PropertyFormula:
+		return v.Formula, true
+	case PropertyRelation:
+		if v.Relation == nil {
+			return []*ObjectReference{}, true
+		}
+		return v.Relation, true
+	case PropertyRollup:
+		return v.Rollup, true
+	case PropertyPeople:
+		if v.People == nil {
+			return []*User{}, true
+		}
+		return v.People, true
+	case PropertyFile:
+		if v.Files == nil {
+			return []*File{}, true
+		}
+		return v.Files, true
+	case PropertyCheckbox:
+		return v.Checkbox, true
+	case PropertyURL:
+		return nullIfEmpty(v.URL), true
+	case PropertyEmail:
+		return nullIfEmpty(v.Email), true
+	case PropertyPhoneNumber:
+		return nullIfEmpty(v.PhoneNumber), true
+	case PropertyCreatedBy:
+		return v.CreatedBy, true
+	case PropertyLastEditedBy:
+		return v.LastEditedBy, true
+	case PropertyCreatedTime:
+		return v.CreatedTime, true
+	case PropertyLastEditedTime:
+		return v.LastEditedTime, true
+	}
+	return nil, false
+}
+
+func nonNilRichTexts(texts []*RichText) []*RichText {
+	if texts == nil {
+		return []*RichText{}
+	}
+	return texts
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// NewClearPropertyValue creates a PropertyValue which clears the property of type,
+// i.e. null number, select and date, empty texts, relations and files, and unchecked checkbox.
+func NewClearPropertyValue(propertyType PropertyType) *PropertyValue {
+	return &PropertyValue{Type: propertyType, null: true}
 }
 
 // NewTitlePropertyValue creates a TitlePropertyValue.
@@ -208,15 +366,163 @@ func NewPhoneNumberPropertyValue(phoneNumber string) *PropertyValue {
 	return &PropertyValue{Type: PropertyPhoneNumber, PhoneNumber: phoneNumber}
 }
 
-// File reference is an object with an name property,
-// with a string value corresponding to a filename of the original file upload (i.e. "Whole_Earth_Catalog.jpg").
-type File struct {
-	Name string `json:"name,omitempty"`
-}
-
 // Date represents a datetime or time range.
 type Date struct {
-	Start time.Time
+	Start DateTime `json:"start"`
 	// If null, this property's date value is not a range.
-	End *time.Time
+	End *DateTime `json:"end"`
+	// TimeZone is the IANA time zone of the date, i.e. "America/New_York".
+	// When present, Start and End are encoded as local time of the zone without offset.
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// MarshalJSON encodes Date, times are encoded in TimeZone if present.
+func (d Date) MarshalJSON() ([]byte, error) {
+	type Alias Date
+	if d.TimeZone == "" {
+		return json.Marshal(Alias(d))
+	}
+	loc, err := time.LoadLocation(d.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Start string  `json:"start"`
+		End   *string `json:"end"`
+		Alias
+	}{
+		Start: d.Start.formatIn(loc),
+		End:   d.End.formatPtrIn(loc),
+		Alias: Alias(d),
+	})
+}
+
+// UnmarshalJSON decodes Date, datetimes without offset are resolved in TimeZone if present, otherwise in UTC.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Start    *string `json:"start"`
+		End      *string `json:"end"`
+		TimeZone string  `json:"time_zone"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	loc := time.UTC
+	if raw.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(raw.TimeZone); err != nil {
+			return err
+		}
+	}
+	*d = Date{TimeZone: raw.TimeZone}
+	if raw.Start != nil {
+		start, err := parseDateTime(*raw.Start, loc)
+		if err != nil {
+			return err
+		}
+		d.Start = start
+	}
+	if raw.End != nil {
+		end, err := parseDateTime(*raw.End, loc)
+		if err != nil {
+			return err
+		}
+		d.End = &end
+	}
+	return nil
+}
+
+var (
+	_ json.Marshaler   = Date{}
+	_ json.Unmarshaler = &Date{}
+)
+
+// dateOnlyLayout is the layout of date without time.
+const dateOnlyLayout = "2006-01-02"
+
+// localDateTimeLayout is the layout of datetime without offset, which is used with time zone.
+const localDateTimeLayout = "2006-01-02T15:04:05.999999999"
+
+// DateTime is a date with or without time.
+// It remembers whether it's date-only, so that values are encoded as the same form as they were decoded.
+type DateTime struct {
+	time.Time
+	// DateOnly reports whether the value has no time component, i.e. "2021-05-13".
+	DateOnly bool
+}
+
+// NewDateTime creates a DateTime with time component.
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{Time: t}
+}
+
+// NewDateOnly creates a date-only DateTime from the date of t.
+func NewDateOnly(t time.Time) DateTime {
+	return DateTime{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), DateOnly: true}
+}
+
+// MarshalJSON encodes DateTime to date-only or RFC 3339 string.
+func (t DateTime) MarshalJSON() ([]byte, error) {
+	if t.DateOnly {
+		return json.Marshal(t.Format(dateOnlyLayout))
+	}
+	return t.Time.MarshalJSON()
+}
+
+// UnmarshalJSON decodes DateTime from date-only or RFC 3339 string.
+// Datetimes without offset are decoded in UTC, use Date to resolve them in its TimeZone.
+func (t *DateTime) UnmarshalJSON(b []byte) error {
+	var s *string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == nil {
+		*t = DateTime{}
+		return nil
+	}
+	v, err := parseDateTime(*s, time.UTC)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// parseDateTime parses date-only, RFC 3339 or local datetime without offset, which is resolved in loc.
+func parseDateTime(s string, loc *time.Location) (DateTime, error) {
+	if len(s) == len(dateOnlyLayout) {
+		v, err := time.Parse(dateOnlyLayout, s)
+		if err != nil {
+			return DateTime{}, err
+		}
+		return DateTime{Time: v, DateOnly: true}, nil
+	}
+	v, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		var localErr error
+		if v, localErr = time.ParseInLocation(localDateTimeLayout, s, loc); localErr != nil {
+			return DateTime{}, err
+		}
+	}
+	return DateTime{Time: v}, nil
+}
+
+var (
+	_ json.Marshaler   = DateTime{}
+	_ json.Unmarshaler = &DateTime{}
+)
+
+func (t DateTime) formatIn(loc *time.Location) string {
+	if t.DateOnly {
+		return t.Format(dateOnlyLayout)
+	}
+	return t.In(loc).Format(localDateTimeLayout)
+}
+
+func (t *DateTime) formatPtrIn(loc *time.Location) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.formatIn(loc)
+	return &s
 }
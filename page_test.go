@@ -1,10 +1,15 @@
 package notion
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPage_MarshalJSON(t *testing.T) {
@@ -13,3 +18,203 @@ func TestPage_MarshalJSON(t *testing.T) {
 	json.Unmarshal(b, &p)
 	assert.Equal(t, ObjectPage, p.Object)
 }
+
+func TestNewFilesPropertyValue(t *testing.T) {
+	b, err := json.Marshal(NewFilesPropertyValue(&File{Name: "a.txt"}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"files","files":[{"name":"a.txt"}]}`, string(b))
+}
+
+func TestClient_UpdatePage(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/v1/pages/page", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		_, _ = w.Write([]byte(`{"object":"page","id":"page","archived":true}`))
+	}, Settings{})
+	ctx := context.Background()
+
+	page, err := client.ArchivePage(ctx, "page")
+	require.NoError(t, err)
+	assert.True(t, page.Archived)
+	_, err = client.RestorePage(ctx, "page")
+	require.NoError(t, err)
+	_, err = client.UpdatePage(ctx, "page", UpdatePageParam{
+		Properties: map[string]*PropertyValue{"Done": NewCheckboxPropertyValue(true)},
+	})
+	require.NoError(t, err)
+	_, err = client.UpdatePage(ctx, "page", UpdatePageParam{
+		Icon:  NewEmojiIcon("🎉"),
+		Cover: NewExternalCover("https://example.com/cover.png"),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, bodies, 4)
+	assert.JSONEq(t, `{"archived":true}`, bodies[0])
+	assert.JSONEq(t, `{"archived":false}`, bodies[1])
+	assert.JSONEq(t, `{"properties":{"Done":{"type":"checkbox","checkbox":true}}}`, bodies[2])
+	assert.JSONEq(t, `{
+		"icon":{"type":"emoji","emoji":"🎉"},
+		"cover":{"type":"external","external":{"url":"https://example.com/cover.png"}}
+	}`, bodies[3])
+}
+
+func TestDate_JSON(t *testing.T) {
+	for _, raw := range []string{
+		`{"start":"2021-05-13","end":null}`,
+		`{"start":"2021-05-13","end":"2021-05-20"}`,
+		`{"start":"2021-05-13T10:30:00+08:00","end":null}`,
+		`{"start":"2021-05-13T10:30:00.5Z","end":"2021-05-14"}`,
+	} {
+		var d Date
+		require.NoError(t, json.Unmarshal([]byte(raw), &d), raw)
+		b, err := json.Marshal(d)
+		require.NoError(t, err)
+		assert.JSONEq(t, raw, string(b))
+	}
+
+	var d Date
+	require.NoError(t, json.Unmarshal([]byte(`{"start":"2021-05-13","end":null}`), &d))
+	assert.True(t, d.Start.DateOnly)
+	assert.Equal(t, time.Date(2021, 5, 13, 0, 0, 0, 0, time.UTC), d.Start.Time)
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database is unavailable")
+	}
+	d = Date{Start: NewDateTime(time.Date(2021, 5, 13, 12, 0, 0, 0, loc)), TimeZone: "America/New_York"}
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"start":"2021-05-13T12:00:00","end":null,"time_zone":"America/New_York"}`, string(b))
+
+	end := NewDateTime(time.Date(2021, 5, 14, 9, 30, 0, 0, time.UTC))
+	d.End = &end
+	b, err = json.Marshal(d)
+	require.NoError(t, err)
+	var decoded Date
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, "America/New_York", decoded.TimeZone)
+	assert.True(t, d.Start.Equal(decoded.Start.Time), decoded.Start.Time)
+	require.NotNil(t, decoded.End)
+	assert.True(t, end.Equal(decoded.End.Time), decoded.End.Time)
+	b2, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(b), string(b2))
+}
+
+func TestDateFilterCondition_JSON(t *testing.T) {
+	day := NewDateOnly(time.Date(2021, 5, 13, 23, 0, 0, 0, time.Local))
+	at := NewDateTime(time.Date(2021, 5, 13, 10, 30, 0, 0, time.UTC))
+	b, err := json.Marshal(&DateFilterCondition{OnOrAfter: &day, Before: &at, PastWeek: &struct{}{}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"on_or_after":"2021-05-13","before":"2021-05-13T10:30:00Z","past_week":{}}`, string(b))
+}
+
+func TestPropertyValue_MarshalJSON(t *testing.T) {
+	date := &Date{Start: NewDateOnly(time.Date(2021, 5, 13, 0, 0, 0, 0, time.UTC))}
+	cases := []struct {
+		value *PropertyValue
+		json  string
+	}{
+		{NewTitlePropertyValue(), `{"type":"title","title":[]}`},
+		{NewRichTextPropertyValue(&RichText{Type: RichTextText, Text: &Text{Content: "a"}}), `{"type":"rich_text","rich_text":[{"type":"text","text":{"content":"a"},"annotations":{}}]}`},
+		{NewNumberPropertyValue(0), `{"type":"number","number":0}`},
+		{NewSelectPropertyValue(nil), `{"type":"select","select":null}`},
+		{NewSelectPropertyValue(&SelectOption{Name: "a"}), `{"type":"select","select":{"name":"a"}}`},
+		{NewMultiSelectPropertyValue(), `{"type":"multi_select","multi_select":[]}`},
+		{NewDatePropertyValue(nil), `{"type":"date","date":null}`},
+		{NewDatePropertyValue(date), `{"type":"date","date":{"start":"2021-05-13","end":null}}`},
+		{NewRelationPropertyValue(), `{"type":"relation","relation":[]}`},
+		{NewPeoplePropertyValue(), `{"type":"people","people":[]}`},
+		{NewFilesPropertyValue(), `{"type":"files","files":[]}`},
+		{NewCheckboxPropertyValue(false), `{"type":"checkbox","checkbox":false}`},
+		{NewURLPropertyValue(""), `{"type":"url","url":null}`},
+		{NewEmailPropertyValue("a@example.com"), `{"type":"email","email":"a@example.com"}`},
+		{NewPhoneNumberPropertyValue(""), `{"type":"phone_number","phone_number":null}`},
+		{&PropertyValue{ID: "f", Type: PropertyFormula, Formula: &FormulaValue{Type: FormulaValueNumber, Number: 1}}, `{"id":"f","type":"formula","formula":{"type":"number","number":1}}`},
+		{&PropertyValue{Type: PropertyRollup}, `{"type":"rollup","rollup":null}`},
+		{&PropertyValue{Type: PropertyCreatedBy, CreatedBy: &User{ID: "u"}}, `{"type":"created_by","created_by":{"id":"u"}}`},
+		{&PropertyValue{Type: PropertyLastEditedBy}, `{"type":"last_edited_by","last_edited_by":null}`},
+		{&PropertyValue{Type: PropertyCreatedTime}, `{"type":"created_time","created_time":null}`},
+		{&PropertyValue{Type: PropertyLastEditedTime}, `{"type":"last_edited_time","last_edited_time":null}`},
+		{&PropertyValue{Number: 1}, `{"number":1}`},
+	}
+	for _, c := range cases {
+		b, err := json.Marshal(c.value)
+		require.NoError(t, err)
+		assert.JSONEq(t, c.json, string(b), c.value.Type)
+	}
+}
+
+func TestNewClearPropertyValue(t *testing.T) {
+	cases := map[PropertyType]string{
+		PropertyTitle:       `[]`,
+		PropertyRichText:    `[]`,
+		PropertyNumber:      `null`,
+		PropertySelect:      `null`,
+		PropertyMultiSelect: `[]`,
+		PropertyDate:        `null`,
+		PropertyRelation:    `[]`,
+		PropertyPeople:      `[]`,
+		PropertyFile:        `[]`,
+		PropertyCheckbox:    `false`,
+		PropertyURL:         `null`,
+		PropertyEmail:       `null`,
+		PropertyPhoneNumber: `null`,
+	}
+	for typ, value := range cases {
+		b, err := json.Marshal(NewClearPropertyValue(typ))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type":"`+string(typ)+`","`+string(typ)+`":`+value+`}`, string(b))
+	}
+}
+
+func TestPropertyValue_UnmarshalJSON(t *testing.T) {
+	for _, raw := range []string{
+		`{"id":"a","type":"number","number":null}`,
+		`{"id":"a","type":"number","number":0}`,
+		`{"id":"a","type":"checkbox","checkbox":false}`,
+		`{"id":"a","type":"select","select":null}`,
+	} {
+		var v PropertyValue
+		require.NoError(t, json.Unmarshal([]byte(raw), &v))
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		assert.JSONEq(t, raw, string(b))
+	}
+}
+
+func TestClient_CreatePageWithParam(t *testing.T) {
+	var body string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		_, _ = w.Write([]byte(`{
+			"object":"page",
+			"id":"page",
+			"url":"https://www.notion.so/page",
+			"icon":{"type":"emoji","emoji":"🎉"},
+			"cover":{"type":"file","file":{"url":"https://s3/cover.png","expiry_time":"2021-05-13T10:00:00.000Z"}}
+		}`))
+	}, Settings{})
+
+	page, err := client.CreatePageWithParam(context.Background(), CreatePageParam{
+		Parent:     NewDatabaseParent("database"),
+		Properties: map[string]*PropertyValue{},
+		Icon:       NewEmojiIcon("🎉"),
+		Cover:      NewExternalCover("https://example.com/cover.png"),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"parent":{"database_id":"database"},
+		"properties":{},
+		"icon":{"type":"emoji","emoji":"🎉"},
+		"cover":{"type":"external","external":{"url":"https://example.com/cover.png"}}
+	}`, body)
+	assert.Equal(t, "https://www.notion.so/page", page.URL)
+	assert.Equal(t, "🎉", page.Icon.Emoji)
+	assert.Equal(t, FileHosted, page.Cover.Type)
+	assert.Equal(t, "https://s3/cover.png", page.Cover.File.URL)
+}
@@ -1,5 +1,7 @@
 package notion
 
+import "context"
+
 // List is Pagination response type.
 type List struct {
 	Object     ObjectType `json:"object,omitempty"`
@@ -7,3 +9,279 @@ type List struct {
 	NextCursor string     `json:"next_cursor,omitempty"`
 	HasMore    bool       `json:"has_more,omitempty"`
 }
+
+// fetchFunc fetches a page of objects start from cursor.
+type fetchFunc func(ctx context.Context, cursor string) (*List, error)
+
+// iterator walks through paginated objects page by page.
+type iterator struct {
+	fetch  fetchFunc
+	buf    Objects
+	cur    *Object
+	cursor string
+	done   bool
+	err    error
+	// finish is called once after all values are consumed.
+	finish func(ctx context.Context) error
+}
+
+func (it *iterator) next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if it.done {
+			it.cur = nil
+			if it.finish != nil {
+				it.err = it.finish(ctx)
+				it.finish = nil
+			}
+			return false
+		}
+		list, err := it.fetch(ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.buf = list.Results
+		it.cursor = list.NextCursor
+		it.done = !list.HasMore || list.NextCursor == ""
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Err returns the error occurred during iteration.
+func (it *iterator) Err() error { return it.err }
+
+// Cursor returns the start cursor of the next page to fetch.
+// Values of the current fetched page that have not been consumed are not covered by the cursor.
+// It's empty when iteration is finished, or when the first page is not fetched and no start cursor is given.
+func (it *iterator) Cursor() string {
+	if it.done {
+		return ""
+	}
+	return it.cursor
+}
+
+// collect consumes objects until iteration finished or limit reached, limit <= 0 means no limit.
+func (it *iterator) collect(ctx context.Context, limit int, fn func(o *Object)) error {
+	for n := 0; limit <= 0 || n < limit; n++ {
+		if !it.next(ctx) {
+			break
+		}
+		fn(it.cur)
+	}
+	return it.err
+}
+
+func wrapObjects(n int, fn func(i int) *Object) Objects {
+	objects := make(Objects, 0, n)
+	for i := 0; i < n; i++ {
+		objects = append(objects, fn(i))
+	}
+	return objects
+}
+
+// PageIterator iterates pages.
+type PageIterator struct{ iterator }
+
+// Next fetches the next page if needed, and reports whether there is a value.
+func (it *PageIterator) Next(ctx context.Context) bool { return it.next(ctx) }
+
+// Value returns the current page.
+func (it *PageIterator) Value() *Page { return it.cur.Page() }
+
+// CollectAll collects remaining pages, limit <= 0 means no limit.
+func (it *PageIterator) CollectAll(ctx context.Context, limit int) ([]*Page, error) {
+	var results []*Page
+	err := it.collect(ctx, limit, func(o *Object) { results = append(results, o.Page()) })
+	return results, err
+}
+
+// NewQueryDatabaseIterator creates PageIterator for API.QueryDatabase.
+func NewQueryDatabaseIterator(api API, databaseID string, param QueryDatabaseParam) *PageIterator {
+	return &PageIterator{iterator{
+		cursor: param.StartCursor,
+		fetch: func(ctx context.Context, cursor string) (*List, error) {
+			param.StartCursor = cursor
+			pages, nextCursor, hasMore, err := api.QueryDatabase(ctx, databaseID, param)
+			if err != nil {
+				return nil, err
+			}
+			return &List{
+				Object:     ObjectList,
+				Results:    wrapObjects(len(pages), func(i int) *Object { return &Object{Type: ObjectPage, page: pages[i]} }),
+				NextCursor: nextCursor,
+				HasMore:    hasMore,
+			}, nil
+		},
+	}}
+}
+
+// DatabaseIterator iterates databases.
+type DatabaseIterator struct{ iterator }
+
+// Next fetches the next page if needed, and reports whether there is a value.
+func (it *DatabaseIterator) Next(ctx context.Context) bool { return it.next(ctx) }
+
+// Value returns the current database.
+func (it *DatabaseIterator) Value() *Database { return it.cur.Database() }
+
+// CollectAll collects remaining databases, limit <= 0 means no limit.
+func (it *DatabaseIterator) CollectAll(ctx context.Context, limit int) ([]*Database, error) {
+	var results []*Database
+	err := it.collect(ctx, limit, func(o *Object) { results = append(results, o.Database()) })
+	return results, err
+}
+
+// NewListDatabasesIterator creates DatabaseIterator for API.ListDatabases.
+func NewListDatabasesIterator(api API, pageSize int32) *DatabaseIterator {
+	return &DatabaseIterator{iterator{
+		fetch: func(ctx context.Context, cursor string) (*List, error) {
+			databases, nextCursor, hasMore, err := api.ListDatabases(ctx, pageSize, cursor)
+			if err != nil {
+				return nil, err
+			}
+			return &List{
+				Object:     ObjectList,
+				Results:    wrapObjects(len(databases), func(i int) *Object { return &Object{Type: ObjectDatabase, database: databases[i]} }),
+				NextCursor: nextCursor,
+				HasMore:    hasMore,
+			}, nil
+		},
+	}}
+}
+
+// BlockIterator iterates blocks.
+type BlockIterator struct{ iterator }
+
+// Next fetches the next page if needed, and reports whether there is a value.
+func (it *BlockIterator) Next(ctx context.Context) bool { return it.next(ctx) }
+
+// Value returns the current block.
+func (it *BlockIterator) Value() *Block { return it.cur.Block() }
+
+// CollectAll collects remaining blocks, limit <= 0 means no limit.
+func (it *BlockIterator) CollectAll(ctx context.Context, limit int) ([]*Block, error) {
+	var results []*Block
+	err := it.collect(ctx, limit, func(o *Object) { results = append(results, o.Block()) })
+	return results, err
+}
+
+// NewBlockChildrenIterator creates BlockIterator for API.RetrieveBlockChildren.
+func NewBlockChildrenIterator(api API, blockID string, pageSize int32) *BlockIterator {
+	return &BlockIterator{iterator{
+		fetch: func(ctx context.Context, cursor string) (*List, error) {
+			blocks, nextCursor, hasMore, err := api.RetrieveBlockChildren(ctx, blockID, pageSize, cursor)
+			if err != nil {
+				return nil, err
+			}
+			return &List{
+				Object:     ObjectList,
+				Results:    wrapObjects(len(blocks), func(i int) *Object { return &Object{Type: ObjectBlock, block: blocks[i]} }),
+				NextCursor: nextCursor,
+				HasMore:    hasMore,
+			}, nil
+		},
+	}}
+}
+
+// UserIterator iterates users.
+type UserIterator struct{ iterator }
+
+// Next fetches the next page if needed, and reports whether there is a value.
+func (it *UserIterator) Next(ctx context.Context) bool { return it.next(ctx) }
+
+// Value returns the current user.
+func (it *UserIterator) Value() *User { return it.cur.User() }
+
+// CollectAll collects remaining users, limit <= 0 means no limit.
+func (it *UserIterator) CollectAll(ctx context.Context, limit int) ([]*User, error) {
+	var results []*User
+	err := it.collect(ctx, limit, func(o *Object) { results = append(results, o.User()) })
+	return results, err
+}
+
+// NewListAllUsersIterator creates UserIterator for API.ListAllUsers.
+func NewListAllUsersIterator(api API, pageSize int32) *UserIterator {
+	return &UserIterator{iterator{
+		fetch: func(ctx context.Context, cursor string) (*List, error) {
+			users, nextCursor, hasMore, err := api.ListAllUsers(ctx, pageSize, cursor)
+			if err != nil {
+				return nil, err
+			}
+			return &List{
+				Object:     ObjectList,
+				Results:    wrapObjects(len(users), func(i int) *Object { return &Object{Type: ObjectUser, user: users[i]} }),
+				NextCursor: nextCursor,
+				HasMore:    hasMore,
+			}, nil
+		},
+	}}
+}
+
+// CommentIterator iterates comments.
+type CommentIterator struct{ iterator }
+
+// Next fetches the next page if needed, and reports whether there is a value.
+func (it *CommentIterator) Next(ctx context.Context) bool { return it.next(ctx) }
+
+// Value returns the current comment.
+func (it *CommentIterator) Value() *Comment { return it.cur.Comment() }
+
+// CollectAll collects remaining comments, limit <= 0 means no limit.
+func (it *CommentIterator) CollectAll(ctx context.Context, limit int) ([]*Comment, error) {
+	var results []*Comment
+	err := it.collect(ctx, limit, func(o *Object) { results = append(results, o.Comment()) })
+	return results, err
+}
+
+// NewListCommentsIterator creates CommentIterator for API.ListComments.
+func NewListCommentsIterator(api API, blockID string, pageSize int32) *CommentIterator {
+	return &CommentIterator{iterator{
+		fetch: func(ctx context.Context, cursor string) (*List, error) {
+			comments, nextCursor, hasMore, err := api.ListComments(ctx, blockID, pageSize, cursor)
+			if err != nil {
+				return nil, err
+			}
+			return &List{
+				Object:     ObjectList,
+				Results:    wrapObjects(len(comments), func(i int) *Object { return &Object{Type: ObjectComment, comment: comments[i]} }),
+				NextCursor: nextCursor,
+				HasMore:    hasMore,
+			}, nil
+		},
+	}}
+}
+
+// ObjectIterator iterates mixed objects.
+type ObjectIterator struct{ iterator }
+
+// Next fetches the next page if needed, and reports whether there is a value.
+func (it *ObjectIterator) Next(ctx context.Context) bool { return it.next(ctx) }
+
+// Value returns the current object.
+func (it *ObjectIterator) Value() *Object { return it.cur }
+
+// CollectAll collects remaining objects, limit <= 0 means no limit.
+func (it *ObjectIterator) CollectAll(ctx context.Context, limit int) (Objects, error) {
+	var results Objects
+	err := it.collect(ctx, limit, func(o *Object) { results = append(results, o) })
+	return results, err
+}
+
+// NewSearchIterator creates ObjectIterator for API.Search.
+func NewSearchIterator(api API, param SearchParam) *ObjectIterator {
+	return &ObjectIterator{iterator{
+		cursor: param.StartCursor,
+		fetch: func(ctx context.Context, cursor string) (*List, error) {
+			param.StartCursor = cursor
+			objects, nextCursor, hasMore, err := api.Search(ctx, param)
+			if err != nil {
+				return nil, err
+			}
+			return &List{Object: ObjectList, Results: objects, NextCursor: nextCursor, HasMore: hasMore}, nil
+		},
+	}}
+}
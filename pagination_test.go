@@ -0,0 +1,80 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedHandler serves total pages in pages of size, the cursor is the index of the first item.
+func pagedHandler(t *testing.T, object ObjectType, total, size int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("start_cursor")
+		if r.Method == http.MethodPost {
+			var param QueryDatabaseParam
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&param))
+			cursor = param.StartCursor
+		}
+		start, _ := strconv.Atoi(cursor)
+		end := start + size
+		if end > total {
+			end = total
+		}
+		results := make([]map[string]interface{}, 0, size)
+		for i := start; i < end; i++ {
+			results = append(results, map[string]interface{}{"object": object, "id": fmt.Sprint(i)})
+		}
+		list := map[string]interface{}{"object": ObjectList, "results": results, "has_more": end < total}
+		if end < total {
+			list["next_cursor"] = fmt.Sprint(end)
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}
+}
+
+func TestPageIterator(t *testing.T) {
+	client := newTestClient(t, pagedHandler(t, ObjectPage, 7, 3), Settings{})
+	ctx := context.Background()
+
+	it := NewQueryDatabaseIterator(client, "database", QueryDatabaseParam{PageSize: 3})
+	var ids []string
+	for it.Next(ctx) {
+		ids = append(ids, it.Value().ID)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6"}, ids)
+	assert.Equal(t, "", it.Cursor())
+
+	it = NewQueryDatabaseIterator(client, "database", QueryDatabaseParam{PageSize: 3, StartCursor: "0"})
+	pages, err := it.CollectAll(ctx, 4)
+	require.NoError(t, err)
+	require.Len(t, pages, 4)
+	assert.Equal(t, "3", pages[3].ID)
+	assert.Equal(t, "6", it.Cursor())
+}
+
+func TestUserIterator(t *testing.T) {
+	client := newTestClient(t, pagedHandler(t, ObjectUser, 5, 2), Settings{})
+
+	users, err := NewListAllUsersIterator(client, 2).CollectAll(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Len(t, users, 5)
+}
+
+func TestIterator_Err(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, ErrCodeObjectNotFound)
+	}, Settings{})
+
+	it := NewBlockChildrenIterator(client, "block", 10)
+	assert.False(t, it.Next(context.Background()))
+	e, ok := AsError(it.Err())
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeObjectNotFound, e.Code)
+}
@@ -0,0 +1,128 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// propertyItemLimit is the max number of references returned in page properties.
+const propertyItemLimit = 25
+
+// propertyItemList is the response of retrieving page property.
+// It's either a single property item, or a list of property items for paginated property types.
+type propertyItemList struct {
+	Object       ObjectType        `json:"object"`
+	Results      []json.RawMessage `json:"results"`
+	NextCursor   string            `json:"next_cursor"`
+	HasMore      bool              `json:"has_more"`
+	PropertyItem *propertyItem     `json:"property_item"`
+}
+
+// propertyItem is an item of paginated property types, which contains a single element of the value.
+type propertyItem struct {
+	ID       string           `json:"id"`
+	Type     PropertyType     `json:"type"`
+	Title    *RichText        `json:"title"`
+	RichText *RichText        `json:"rich_text"`
+	Relation *ObjectReference `json:"relation"`
+	People   *User            `json:"people"`
+	Rollup   *RollupValue     `json:"rollup"`
+}
+
+// decodePropertyItem decodes a property item to PropertyValue.
+func decodePropertyItem(b []byte) (*PropertyValue, error) {
+	var item propertyItem
+	if err := json.Unmarshal(b, &item); err != nil {
+		return nil, err
+	}
+	value := &PropertyValue{ID: item.ID, Type: item.Type}
+	switch item.Type {
+	case PropertyTitle:
+		value.Title = appendNonNil(value.Title, item.Title)
+	case PropertyRichText:
+		value.RichText = appendNonNil(value.RichText, item.RichText)
+	case PropertyRelation:
+		if item.Relation != nil {
+			value.Relation = append(value.Relation, item.Relation)
+		}
+	case PropertyPeople:
+		if item.People != nil {
+			value.People = append(value.People, item.People)
+		}
+	default:
+		if err := json.Unmarshal(b, value); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+func appendNonNil(texts []*RichText, text *RichText) []*RichText {
+	if text == nil {
+		return texts
+	}
+	return append(texts, text)
+}
+
+// merge appends elements of paginated item to the value.
+func (v *PropertyValue) merge(item *PropertyValue) {
+	switch v.Type {
+	case PropertyTitle:
+		v.Title = append(v.Title, item.Title...)
+	case PropertyRichText:
+		v.RichText = append(v.RichText, item.RichText...)
+	case PropertyRelation:
+		v.Relation = append(v.Relation, item.Relation...)
+	case PropertyPeople:
+		v.People = append(v.People, item.People...)
+	case PropertyRollup:
+		if v.Rollup != nil && v.Rollup.Type == RolluoValueArray {
+			v.Rollup.Array = append(v.Rollup.Array, item)
+		}
+	}
+}
+
+// truncated reports whether the property value in page may be truncated.
+func (v *PropertyValue) truncated() bool {
+	if v.HasMore {
+		return true
+	}
+	switch v.Type {
+	case PropertyTitle:
+		return len(v.Title) >= propertyItemLimit
+	case PropertyRichText:
+		return len(v.RichText) >= propertyItemLimit
+	case PropertyRelation:
+		return len(v.Relation) >= propertyItemLimit
+	case PropertyPeople:
+		return len(v.People) >= propertyItemLimit
+	}
+	return false
+}
+
+// HydratePageProperties retrieves the complete values of the truncated properties of page,
+// including relations, people, texts and rollups, and replaces them in page.Properties.
+// Rollups are evaluated against the relations of page, so they're retrieved only if any relation is truncated.
+func HydratePageProperties(ctx context.Context, api API, page *Page) error {
+	var relationTruncated bool
+	for _, value := range page.Properties {
+		if value.Type == PropertyRelation && value.truncated() {
+			relationTruncated = true
+		}
+	}
+	for name, value := range page.Properties {
+		truncated := value.truncated()
+		if value.Type == PropertyRollup {
+			truncated = relationTruncated
+		}
+		if value.ID == "" || !truncated {
+			continue
+		}
+		v, err := api.RetrievePageProperty(ctx, page.ID, value.ID)
+		if err != nil {
+			return err
+		}
+		page.Properties[name] = *v
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func propertyItemHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/pages/page/properties/num":
+			_, _ = w.Write([]byte(`{"object":"property_item","id":"num","type":"number","number":3}`))
+		case "/v1/pages/page/properties/rel", "/v1/pages/page/properties/sum", "/v1/pages/page/properties/avg":
+			start, _ := strconv.Atoi(r.URL.Query().Get("start_cursor"))
+			end := start + 25
+			if end > 30 {
+				end = 30
+			}
+			var results []interface{}
+			for i := start; i < end; i++ {
+				results = append(results, map[string]interface{}{
+					"object": "property_item", "id": "rel", "type": "relation",
+					"relation": map[string]interface{}{"id": fmt.Sprint(i)},
+				})
+			}
+			list := map[string]interface{}{"object": "list", "results": results, "has_more": end < 30}
+			if end < 30 {
+				list["next_cursor"] = fmt.Sprint(end)
+			}
+			switch {
+			case r.URL.Path == "/v1/pages/page/properties/avg":
+				// The aggregated value is only final on the last page.
+				number := 0.0
+				if end == 30 {
+					number = 14.5
+				}
+				list["property_item"] = map[string]interface{}{
+					"id": "avg", "type": "rollup",
+					"rollup": map[string]interface{}{"type": "number", "function": "average", "number": number},
+				}
+			case r.URL.Path == "/v1/pages/page/properties/sum":
+				list["property_item"] = map[string]interface{}{
+					"id": "sum", "type": "rollup",
+					"rollup": map[string]interface{}{"type": "array", "function": "show_original"},
+				}
+			default:
+				list["property_item"] = map[string]interface{}{"id": "rel", "type": "relation", "relation": map[string]interface{}{}}
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}
+}
+
+func TestClient_RetrievePageProperty(t *testing.T) {
+	client := newTestClient(t, propertyItemHandler(t), Settings{})
+	ctx := context.Background()
+
+	v, err := client.RetrievePageProperty(ctx, "page", "num")
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, v.Number)
+
+	v, err = client.RetrievePageProperty(ctx, "page", "rel")
+	require.NoError(t, err)
+	assert.Equal(t, PropertyRelation, v.Type)
+	require.Len(t, v.Relation, 30)
+	assert.Equal(t, "29", v.Relation[29].ID)
+
+	v, err = client.RetrievePageProperty(ctx, "page", "sum")
+	require.NoError(t, err)
+	require.NotNil(t, v.Rollup)
+	assert.Equal(t, RollupShowOriginal, v.Rollup.Function)
+	require.Len(t, v.Rollup.Array, 30)
+	assert.Equal(t, "0", v.Rollup.Array[0].Relation[0].ID)
+
+	v, err = client.RetrievePageProperty(ctx, "page", "avg")
+	require.NoError(t, err)
+	require.NotNil(t, v.Rollup)
+	assert.Equal(t, RollupValueNumber, v.Rollup.Type)
+	assert.Equal(t, 14.5, v.Rollup.Number)
+	assert.Empty(t, v.Rollup.Array)
+}
+
+func TestHydratePageProperties(t *testing.T) {
+	client := newTestClient(t, propertyItemHandler(t), Settings{})
+
+	relations := make([]*ObjectReference, 25)
+	page := &Page{ID: "page", Properties: map[string]PropertyValue{
+		"Number":  {ID: "num", Type: PropertyNumber, Number: 3},
+		"Related": {ID: "rel", Type: PropertyRelation, Relation: relations},
+		"Sum":     {ID: "sum", Type: PropertyRollup, Rollup: &RollupValue{Type: RolluoValueArray}},
+	}}
+	require.NoError(t, HydratePageProperties(context.Background(), client, page))
+	assert.Len(t, page.Properties["Related"].Relation, 30)
+	assert.Len(t, page.Properties["Sum"].Rollup.Array, 30)
+	assert.Equal(t, 3.0, page.Properties["Number"].Number)
+
+	// Rollups are complete if no relation is truncated.
+	page = &Page{ID: "page", Properties: map[string]PropertyValue{
+		"Related": {ID: "rel", Type: PropertyRelation, Relation: relations[:1]},
+		"Sum":     {ID: "sum", Type: PropertyRollup, Rollup: &RollupValue{Type: RolluoValueArray}},
+	}}
+	require.NoError(t, HydratePageProperties(context.Background(), client, page))
+	assert.Empty(t, page.Properties["Sum"].Rollup.Array)
+}
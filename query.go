@@ -0,0 +1,222 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// filterPropertyTypes are the property types each filter condition applies to.
+var filterPropertyTypes = map[string][]PropertyType{
+	"text":         {PropertyTitle, PropertyRichText, PropertyURL, PropertyEmail, PropertyPhoneNumber},
+	"number":       {PropertyNumber},
+	"checkbox":     {PropertyCheckbox},
+	"select":       {PropertySelect},
+	"multi_select": {PropertyMultiSelect},
+	"date":         {PropertyDate, PropertyCreatedTime, PropertyLastEditedTime},
+	"people":       {PropertyPeople, PropertyCreatedBy, PropertyLastEditedBy},
+	"files":        {PropertyFile},
+	"relation":     {PropertyRelation},
+	"formula":      {PropertyFormula},
+}
+
+// formulaConditionTypes are the formula result types each formula condition applies to.
+var formulaConditionTypes = map[string]FormulaValueType{
+	"text":     FormulaValueString,
+	"number":   FormulaValueNumber,
+	"checkbox": FormulaValueBoolen,
+	"date":     FormulaValueDate,
+}
+
+// ValidateQuery checks the filter and sorts of param against the schema of db,
+// so that misspelled properties and mismatched conditions are reported before sending the query.
+// The error is a *QueryError pointing at the first offending filter or sort.
+//
+// Result types of formulas and rollups are not described by the schema,
+// so only the shape of their conditions is checked, unless Client has seen the result type of a formula in query results.
+func ValidateQuery(db *Database, param QueryDatabaseParam) error {
+	if param.Filter != nil {
+		if err := param.Filter.validate("filter", db); err != nil {
+			return err
+		}
+	}
+	for i, sort := range param.Sorts {
+		if err := sort.validate(fmt.Sprintf("sorts[%d]", i), db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// property finds the property by name or ID, both of which are accepted by Notion.
+func (db *Database) property(nameOrID string) (Property, bool) {
+	if p, ok := db.Properties[nameOrID]; ok {
+		return p, true
+	}
+	for _, p := range db.Properties {
+		if p.ID != "" && p.ID == nameOrID {
+			return p, true
+		}
+	}
+	return Property{}, false
+}
+
+// validateSchema checks the condition of leaf filter f applies to the type of its property.
+func (f *Filter) validateSchema(path string, db *Database) error {
+	if f.Timestamp != "" {
+		return nil
+	}
+	p, ok := db.property(f.Property)
+	if !ok {
+		return &QueryError{Path: path, Reason: fmt.Sprintf("property %q does not exist", f.Property)}
+	}
+	if p.Type == PropertyRollup {
+		return nil
+	}
+	name := f.conditions()[0].name
+	if p.Type == PropertyFormula && f.Formula != nil {
+		name := f.Formula.conditions()[0].name
+		if t, ok := db.formulaTypes[p.ID]; ok && t != formulaConditionTypes[name] {
+			return &QueryError{Path: path, Reason: fmt.Sprintf("%s condition doesn't apply to %s result of formula %q", name, t, f.Property)}
+		}
+	}
+	for _, t := range filterPropertyTypes[name] {
+		if t == p.Type {
+			return nil
+		}
+	}
+	return &QueryError{Path: path, Reason: fmt.Sprintf("%s condition doesn't apply to %s property %q", name, p.Type, f.Property)}
+}
+
+// validate checks s sorts by a property or a timestamp, the property must exist in db if it's not nil.
+func (s *Sort) validate(path string, db *Database) error {
+	switch {
+	case s == nil:
+		return &QueryError{Path: path, Reason: "sort is nil"}
+	case s.Direction != DirectionAscending && s.Direction != DirectionDescending:
+		return &QueryError{Path: path, Reason: fmt.Sprintf("unknown direction %q", s.Direction)}
+	case s.Property != "" && s.Timestamp != "":
+		return &QueryError{Path: path, Reason: "both property and timestamp are set"}
+	case s.Property == "" && s.Timestamp == "":
+		return &QueryError{Path: path, Reason: "neither property nor timestamp is set"}
+	case s.Timestamp != "":
+		if s.Timestamp != TimestampCreatedTime && s.Timestamp != TimestampLastEditedTime {
+			return &QueryError{Path: path, Reason: fmt.Sprintf("unknown timestamp %q", s.Timestamp)}
+		}
+		return nil
+	}
+	if db == nil {
+		return nil
+	}
+	if _, ok := db.property(s.Property); !ok {
+		return &QueryError{Path: path, Reason: fmt.Sprintf("property %q does not exist", s.Property)}
+	}
+	return nil
+}
+
+// schemaCache caches database schemas to validate queries,
+// along with result types of formulas seen in query results, which the schemas don't describe.
+type schemaCache struct {
+	mu        sync.Mutex
+	databases map[string]*Database
+	// formulas maps database IDs to result types of formulas by property ID.
+	formulas map[string]map[string]FormulaValueType
+	// refetched marks the schemas refetched after rejecting queries, which are not refetched again.
+	refetched map[string]bool
+}
+
+// get returns the cached schema of the database and whether it has been refetched.
+func (c *schemaCache) get(databaseID string) (*Database, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	db := c.databases[databaseID]
+	if db == nil || len(c.formulas[databaseID]) == 0 {
+		return db, c.refetched[databaseID]
+	}
+	withTypes := *db
+	withTypes.formulaTypes = make(map[string]FormulaValueType, len(c.formulas[databaseID]))
+	for id, t := range c.formulas[databaseID] {
+		withTypes.formulaTypes[id] = t
+	}
+	return &withTypes, c.refetched[databaseID]
+}
+
+func (c *schemaCache) markRefetched(databaseID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.refetched == nil {
+		c.refetched = make(map[string]bool)
+	}
+	c.refetched[databaseID] = true
+}
+
+// put caches the schema of the database, result types of formulas whose expressions have changed are forgotten.
+func (c *schemaCache) put(databaseID string, db *Database) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.databases == nil {
+		c.databases = make(map[string]*Database)
+	}
+	if old := c.databases[databaseID]; old != nil {
+		for id := range c.formulas[databaseID] {
+			if formulaExpression(old, id) != formulaExpression(db, id) {
+				delete(c.formulas[databaseID], id)
+			}
+		}
+	}
+	c.databases[databaseID] = db
+	delete(c.refetched, databaseID)
+}
+
+// formulaExpression returns the expression of formula property id in db, empty if it's not a formula.
+func formulaExpression(db *Database, id string) string {
+	p, ok := db.property(id)
+	if !ok || p.Formula == nil {
+		return ""
+	}
+	return p.Formula.Expression
+}
+
+// observe records result types of formulas in pages queried from the database.
+func (c *schemaCache) observe(databaseID string, pages []*Page) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, page := range pages {
+		for _, v := range page.Properties {
+			if v.Type != PropertyFormula || v.ID == "" || v.Formula == nil || v.Formula.Type == "" {
+				continue
+			}
+			if c.formulas == nil {
+				c.formulas = make(map[string]map[string]FormulaValueType)
+			}
+			if c.formulas[databaseID] == nil {
+				c.formulas[databaseID] = make(map[string]FormulaValueType)
+			}
+			c.formulas[databaseID][v.ID] = v.Formula.Type
+		}
+	}
+}
+
+// validateQuery validates param against the cached schema of the database.
+// The schema is fetched if it isn't cached, and refetched once if it rejects the query, as it may be outdated.
+// The refetched schema is trusted until it's replaced, so that invalid queries don't refetch it every time.
+func (c *Client) validateQuery(ctx context.Context, databaseID string, param QueryDatabaseParam) error {
+	if c.schemas == nil {
+		return nil
+	}
+	db, refetched := c.schemas.get(databaseID)
+	if db != nil {
+		if err := ValidateQuery(db, param); err == nil || refetched {
+			return err
+		}
+	}
+	// RetrieveDatabase caches the schema, which is read back with the result types of formulas.
+	if _, err := c.RetrieveDatabase(ctx, databaseID); err != nil {
+		return err
+	}
+	if db != nil {
+		c.schemas.markRefetched(databaseID)
+	}
+	db, _ = c.schemas.get(databaseID)
+	return ValidateQuery(db, param)
+}
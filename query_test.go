@@ -0,0 +1,183 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testQueryDatabase = &Database{
+	ID: "database",
+	Properties: map[string]Property{
+		"Name":    {ID: "title", Type: PropertyTitle},
+		"Status":  {ID: "a%3Ab", Type: PropertySelect},
+		"Score":   {ID: "c", Type: PropertyNumber},
+		"Created": {ID: "d", Type: PropertyCreatedTime},
+		"Total":   {ID: "e", Type: PropertyFormula},
+		"Sum":     {ID: "f", Type: PropertyRollup},
+	},
+}
+
+func TestValidateQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   QueryDatabaseParam
+		wantErr string
+	}{
+		{
+			name: "valid",
+			param: QueryDatabaseParam{
+				Filter: And(
+					Prop("Name").Text().Contains("a"),
+					Prop("a%3Ab").Select().Equals("Done"),
+					Prop("Created").Date().PastWeek(),
+					Prop("Total").Formula().Checkbox().Equals(true),
+					Prop("Sum").Number().GreaterThan(1),
+					LastEditedTime().NextMonth(),
+				).Filter(),
+				Sorts: []*Sort{SortByProperty("Score", DirectionAscending), SortByCreatedTime(DirectionDescending)},
+			},
+		},
+		{
+			name:    "unknown property",
+			param:   QueryDatabaseParam{Filter: Or(Prop("Name").Text().IsEmpty(), Prop("Stauts").Select().IsEmpty()).Filter()},
+			wantErr: `notion: invalid filter.or[1]: property "Stauts" does not exist`,
+		},
+		{
+			name:    "mismatched condition",
+			param:   QueryDatabaseParam{Filter: Prop("Status").Number().Equals(1).Filter()},
+			wantErr: `notion: invalid filter: number condition doesn't apply to select property "Status"`,
+		},
+		{
+			name:    "formula on non-formula",
+			param:   QueryDatabaseParam{Filter: Prop("Score").Formula().Number().Equals(1).Filter()},
+			wantErr: `notion: invalid filter: formula condition doesn't apply to number property "Score"`,
+		},
+		{
+			name:    "invalid filter",
+			param:   QueryDatabaseParam{Filter: &Filter{Property: "Score"}},
+			wantErr: `notion: invalid filter: no condition is set`,
+		},
+		{
+			name:    "unknown sort property",
+			param:   QueryDatabaseParam{Sorts: []*Sort{SortByCreatedTime(DirectionAscending), SortByProperty("Scroe", DirectionAscending)}},
+			wantErr: `notion: invalid sorts[1]: property "Scroe" does not exist`,
+		},
+		{
+			name:    "sort direction",
+			param:   QueryDatabaseParam{Sorts: []*Sort{{Property: "Score"}}},
+			wantErr: `notion: invalid sorts[0]: unknown direction ""`,
+		},
+		{
+			name:    "sort timestamp",
+			param:   QueryDatabaseParam{Sorts: []*Sort{{Timestamp: "edited", Direction: DirectionAscending}}},
+			wantErr: `notion: invalid sorts[0]: unknown timestamp "edited"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQuery(testQueryDatabase, tt.param)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.IsType(t, &QueryError{}, err)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+
+	// Without schema, only the shape of the query is checked.
+	assert.NoError(t, ValidateQuery(nil, QueryDatabaseParam{
+		Filter: Prop("Stauts").Select().IsEmpty().Filter(),
+		Sorts:  []*Sort{SortByProperty("Scroe", DirectionAscending)},
+	}))
+}
+
+func TestClient_ValidateQueries(t *testing.T) {
+	schemas := []string{
+		`{"object":"database","id":"database","properties":{"Status":{"id":"a","type":"select","select":{}}}}`,
+		`{"object":"database","id":"database","properties":{"Status":{"id":"a","type":"select","select":{}},"Score":{"id":"b","type":"number","number":{}}}}`,
+	}
+	var retrieves, queries int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/databases/database":
+			schema := schemas[len(schemas)-1]
+			if retrieves < len(schemas) {
+				schema = schemas[retrieves]
+			}
+			retrieves++
+			_, _ = w.Write([]byte(schema))
+		case "/v1/databases/database/query":
+			queries++
+			_, _ = w.Write([]byte(`{"object":"list","results":[]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}, Settings{ValidateQueries: true})
+	ctx := context.Background()
+
+	_, _, _, err := client.QueryDatabase(ctx, "database", QueryDatabaseParam{Filter: Prop("Status").Select().Equals("Done").Filter()})
+	require.NoError(t, err)
+	_, _, _, err = client.QueryDatabase(ctx, "database", QueryDatabaseParam{Filter: Prop("Status").Select().IsEmpty().Filter()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, retrieves, "schema is cached")
+
+	// The outdated schema is refetched.
+	_, _, _, err = client.QueryDatabase(ctx, "database", QueryDatabaseParam{Sorts: []*Sort{SortByProperty("Score", DirectionAscending)}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, retrieves)
+
+	// The refetched schema is not refetched again by invalid queries.
+	for i := 0; i < 2; i++ {
+		_, _, _, err = client.QueryDatabase(ctx, "database", QueryDatabaseParam{Filter: Prop("Status").Number().Equals(1).Filter()})
+		assert.IsType(t, &QueryError{}, err)
+	}
+	assert.Equal(t, 2, retrieves)
+	assert.Equal(t, 3, queries)
+
+	// A replaced schema may be refetched again.
+	_, err = client.RetrieveDatabase(ctx, "database")
+	require.NoError(t, err)
+	_, _, _, err = client.QueryDatabase(ctx, "database", QueryDatabaseParam{Filter: Prop("Status").Number().Equals(1).Filter()})
+	assert.IsType(t, &QueryError{}, err)
+	assert.Equal(t, 4, retrieves)
+}
+
+func TestClient_ValidateFormulaQueries(t *testing.T) {
+	schema := `{"object":"database","id":"database","properties":{"Total":{"id":"a","type":"formula","formula":{"expression":"1"}}}}`
+	var retrieves int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/databases/database":
+			retrieves++
+			_, _ = w.Write([]byte(schema))
+		case "/v1/databases/database/query":
+			_, _ = w.Write([]byte(`{"object":"list","results":[{"object":"page","id":"page","properties":{"Total":{"id":"a","type":"formula","formula":{"type":"number","number":1}}}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}, Settings{ValidateQueries: true})
+	ctx := context.Background()
+
+	// The result type is unknown before the first query.
+	_, _, _, err := client.QueryDatabase(ctx, "database", QueryDatabaseParam{Filter: Prop("Total").Formula().Text().Equals("1").Filter()})
+	require.NoError(t, err)
+	_, _, _, err = client.QueryDatabase(ctx, "database", QueryDatabaseParam{Filter: Prop("Total").Formula().Number().Equals(1).Filter()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, retrieves)
+
+	// The result type is forgotten once the expression changes.
+	schema = `{"object":"database","id":"database","properties":{"Total":{"id":"a","type":"formula","formula":{"expression":"\"1\""}}}}`
+	_, _, _, err = client.QueryDatabase(ctx, "database", QueryDatabaseParam{Filter: Prop("Total").Formula().Text().Equals("1").Filter()})
+	require.NoError(t, err)
+	assert.Equal(t, 2, retrieves)
+
+	_, _, _, err = client.QueryDatabase(ctx, "database", QueryDatabaseParam{Filter: Prop("Total").Formula().Checkbox().Equals(true).Filter()})
+	assert.IsType(t, &QueryError{}, err)
+	assert.EqualError(t, err, `notion: invalid filter: checkbox condition doesn't apply to number result of formula "Total"`)
+	assert.Equal(t, 2, retrieves)
+}
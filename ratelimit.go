@@ -0,0 +1,132 @@
+package notion
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the rate of requests sent by Client.
+// A RateLimiter can be shared between multiple Client values built from the same token,
+// so that they respect the rate limit of the integration together.
+type RateLimiter interface {
+	// Wait blocks until a request is allowed to be sent or ctx is done.
+	Wait(ctx context.Context) error
+	// Throttled is called when server responded "rate_limited".
+	// retryAfter is parsed from "Retry-After" header and may be zero.
+	Throttled(retryAfter time.Duration)
+}
+
+// DefaultRequestsPerSecond is the average request rate allowed by Notion for each integration.
+const DefaultRequestsPerSecond = 3
+
+const (
+	// tokenBucketMinRateFactor is the lower bound of adapted rate relative to the configured rate.
+	tokenBucketMinRateFactor = 0.1
+	// tokenBucketRecoverInterval is the interval to increase the adapted rate after being throttled.
+	tokenBucketRecoverInterval = 10 * time.Second
+	// tokenBucketRecoverFactor is the step of rate increasing relative to the configured rate.
+	tokenBucketRecoverFactor = 0.1
+)
+
+// TokenBucket is a RateLimiter implemented with token bucket algorithm.
+// It halves its rate when being throttled by server, and gradually recovers to the configured rate.
+// TokenBucket is safe for concurrent use.
+type TokenBucket struct {
+	mu          sync.Mutex
+	maxRate     float64
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	adjusted    time.Time
+	pausedUntil time.Time
+}
+
+var _ RateLimiter = &TokenBucket{}
+
+// NewTokenBucket creates a TokenBucket which allows rate requests per second with bursts of at most burst requests.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if rate <= 0 {
+		rate = DefaultRequestsPerSecond
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	now := time.Now()
+	return &TokenBucket{
+		maxRate:  rate,
+		rate:     rate,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     now,
+		adjusted: now,
+	}
+}
+
+// Rate returns the current adapted rate in requests per second.
+func (b *TokenBucket) Rate() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+	return b.rate
+}
+
+// Wait implements RateLimiter.Wait.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait, ok := b.reserve(time.Now())
+		if ok {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Throttled implements RateLimiter.Throttled.
+func (b *TokenBucket) Throttled(retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.advance(now)
+	b.rate = math.Max(b.rate/2, b.maxRate*tokenBucketMinRateFactor)
+	b.tokens = 0
+	b.adjusted = now
+	if until := now.Add(retryAfter); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// reserve takes a token if available, otherwise returns the duration to wait.
+func (b *TokenBucket) reserve(now time.Time) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(now)
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now), false
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second)), false
+}
+
+// advance recovers the rate and refills tokens up to now.
+func (b *TokenBucket) advance(now time.Time) {
+	for b.rate < b.maxRate && now.Sub(b.adjusted) >= tokenBucketRecoverInterval {
+		b.rate = math.Min(b.rate+b.maxRate*tokenBucketRecoverFactor, b.maxRate)
+		b.adjusted = b.adjusted.Add(tokenBucketRecoverInterval)
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.tokens+elapsed.Seconds()*b.rate, b.burst)
+		b.last = now
+	}
+}
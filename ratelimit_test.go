@@ -0,0 +1,65 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	t.Run("burst", func(t *testing.T) {
+		b := NewTokenBucket(1, 2)
+		now := time.Now()
+		_, ok := b.reserve(now)
+		assert.True(t, ok)
+		_, ok = b.reserve(now)
+		assert.True(t, ok)
+		wait, ok := b.reserve(now)
+		assert.False(t, ok)
+		assert.InDelta(t, float64(time.Second), float64(wait), float64(10*time.Millisecond))
+	})
+
+	t.Run("adapt rate", func(t *testing.T) {
+		b := NewTokenBucket(4, 1)
+		b.Throttled(0)
+		assert.Equal(t, 2.0, b.Rate())
+		b.Throttled(time.Minute)
+		assert.Equal(t, 1.0, b.Rate())
+		wait, ok := b.reserve(time.Now())
+		assert.False(t, ok)
+		assert.True(t, wait > 50*time.Second)
+
+		b.advance(time.Now().Add(3 * tokenBucketRecoverInterval))
+		assert.InDelta(t, 2.2, b.rate, 0.001)
+		b.advance(time.Now().Add(100 * tokenBucketRecoverInterval))
+		assert.Equal(t, 4.0, b.rate)
+	})
+
+	t.Run("wait canceled", func(t *testing.T) {
+		b := NewTokenBucket(0.01, 1)
+		require.NoError(t, b.Wait(context.Background()))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, context.DeadlineExceeded, b.Wait(ctx))
+	})
+}
+
+func TestClient_RateLimiter(t *testing.T) {
+	limiter := NewTokenBucket(1000, 10)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusTooManyRequests, ErrCodeRateLimited)
+	}
+	c1 := newTestClient(t, handler, Settings{RateLimiter: limiter})
+	c2 := newTestClient(t, handler, Settings{RateLimiter: limiter})
+
+	_, err := c1.RetrieveUser(context.Background(), "user")
+	require.Error(t, err)
+	assert.Equal(t, 500.0, limiter.Rate())
+	_, err = c2.RetrieveUser(context.Background(), "user")
+	require.Error(t, err)
+	assert.Equal(t, 250.0, limiter.Rate())
+}
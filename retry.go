@@ -0,0 +1,105 @@
+package notion
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how Client retries failed requests.
+// Only idempotent requests and explicitly safe POST requests (QueryDatabase and Search) are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Zero or one disables retrying.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, it doubles after each attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay. Zero means no cap.
+	MaxDelay time.Duration
+	// Jitter is the fraction (between 0 and 1) of the delay that is randomized.
+	Jitter float64
+	// RetryableCodes is the set of error codes that should be retried.
+	RetryableCodes []ErrCode
+}
+
+// DefaultRetryPolicy returns a RetryPolicy retrying rate limited, conflict and server errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		RetryableCodes: []ErrCode{
+			ErrCodeRateLimited,
+			ErrCodeConflictError,
+			ErrCodeInternalServerError,
+			ErrCodeServiceUnavailable,
+		},
+	}
+}
+
+// retryable reports whether err should be retried after attempt attempts.
+func (p *RetryPolicy) retryable(attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	e, ok := AsError(err)
+	if !ok {
+		return false
+	}
+	for _, code := range p.RetryableCodes {
+		if e.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// delay computes the waiting duration before the next attempt.
+// Retry-After sent by server takes precedence over the computed backoff.
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	if e, ok := AsError(err); ok && e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+	d := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d -= d * jitter * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// isIdempotent reports whether the HTTP method can be retried safely.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses Retry-After header, which is either delay seconds or a HTTP date.
+func parseRetryAfter(header string) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
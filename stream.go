@@ -0,0 +1,69 @@
+package notion
+
+import "context"
+
+// defaultStreamBuffer is the buffer size of PageStream, which equals the max page size of Notion.
+const defaultStreamBuffer = 100
+
+// PageStream delivers pages of QueryDatabase over a channel,
+// and prefetches the next page in background while the consumer is processing the current one.
+type PageStream struct {
+	pages  chan *Page
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// NewQueryDatabaseStream starts streaming pages of API.QueryDatabase.
+// At most buffer pages are fetched ahead of the consumer, buffer <= 0 means param.PageSize or 100.
+// Streaming stops when ctx is done, the stream is closed or an error occurred.
+func NewQueryDatabaseStream(ctx context.Context, api API, databaseID string, param QueryDatabaseParam, buffer int) *PageStream {
+	if buffer <= 0 {
+		buffer = int(param.PageSize)
+	}
+	if buffer <= 0 {
+		buffer = defaultStreamBuffer
+	}
+	streamCtx, cancel := context.WithCancel(ctx)
+	s := &PageStream{
+		pages:  make(chan *Page, buffer),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go s.run(ctx, streamCtx, NewQueryDatabaseIterator(api, databaseID, param))
+	return s
+}
+
+func (s *PageStream) run(parent, ctx context.Context, it *PageIterator) {
+	defer close(s.done)
+	defer close(s.pages)
+	defer s.cancel()
+
+	for it.Next(ctx) {
+		select {
+		case s.pages <- it.Value():
+		case <-ctx.Done():
+			s.err = parent.Err()
+			return
+		}
+	}
+	if err := it.Err(); err != nil && (ctx.Err() == nil || parent.Err() != nil) {
+		s.err = err
+	}
+}
+
+// Pages returns the channel delivering pages, it's closed when streaming stopped.
+func (s *PageStream) Pages() <-chan *Page { return s.pages }
+
+// Err waits for streaming stopped, and returns the first error occurred.
+// Closing the stream is not considered as an error.
+func (s *PageStream) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Close stops streaming and waits for background fetching finished.
+func (s *PageStream) Close() {
+	s.cancel()
+	<-s.done
+}
@@ -0,0 +1,52 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageStream(t *testing.T) {
+	client := newTestClient(t, pagedHandler(t, ObjectPage, 25, 10), Settings{})
+
+	t.Run("all pages", func(t *testing.T) {
+		s := NewQueryDatabaseStream(context.Background(), client, "database", QueryDatabaseParam{PageSize: 10}, 5)
+		var count int
+		for range s.Pages() {
+			count++
+		}
+		require.NoError(t, s.Err())
+		assert.Equal(t, 25, count)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		s := NewQueryDatabaseStream(context.Background(), client, "database", QueryDatabaseParam{PageSize: 10}, 1)
+		<-s.Pages()
+		s.Close()
+		assert.NoError(t, s.Err())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		s := NewQueryDatabaseStream(ctx, client, "database", QueryDatabaseParam{PageSize: 10}, 1)
+		<-s.Pages()
+		cancel()
+		assert.ErrorIs(t, s.Err(), context.Canceled)
+	})
+}
+
+func TestPageStream_Err(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusBadRequest, ErrCodeValidationError)
+	}, Settings{})
+
+	s := NewQueryDatabaseStream(context.Background(), client, "database", QueryDatabaseParam{}, 0)
+	_, ok := <-s.Pages()
+	assert.False(t, ok)
+	e, ok := AsError(s.Err())
+	require.True(t, ok)
+	assert.Equal(t, ErrCodeValidationError, e.Code)
+}
@@ -21,7 +21,32 @@ type User struct {
 		Email string `json:"email,omitempty"`
 	} `json:"person,omitempty"`
 	// Properties only present for bot users.
-	Bot       *struct{} `json:"bot,omitempty"`
-	Name      string    `json:"name,omitempty"`
-	AvatarURL string    `json:"avatar_url,omitempty"`
+	Bot       *Bot   `json:"bot,omitempty"`
+	Name      string `json:"name,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// BotOwnerType is type of BotOwner.
+type BotOwnerType string
+
+// BotOwnerType enums.
+const (
+	// BotOwnerWorkspace means the bot is owned by the workspace, i.e. internal integration.
+	BotOwnerWorkspace BotOwnerType = "workspace"
+	// BotOwnerUser means the bot is owned by a user, i.e. public integration authorized by the user.
+	BotOwnerUser BotOwnerType = "user"
+)
+
+// BotOwner is the owner of bot.
+type BotOwner struct {
+	Type      BotOwnerType `json:"type,omitempty"`
+	Workspace bool         `json:"workspace,omitempty"`
+	User      *User        `json:"user,omitempty"`
+}
+
+// Bot contains information of bot user.
+type Bot struct {
+	Owner *BotOwner `json:"owner,omitempty"`
+	// WorkspaceName is the name of the workspace which the bot belongs to.
+	WorkspaceName string `json:"workspace_name,omitempty"`
 }
@@ -0,0 +1,33 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RetrieveMe(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/users/me", r.URL.Path)
+		_, _ = w.Write([]byte(`{
+			"object": "user",
+			"id": "bot",
+			"type": "bot",
+			"name": "integration",
+			"bot": {
+				"owner": {"type": "workspace", "workspace": true},
+				"workspace_name": "acme"
+			}
+		}`))
+	}, Settings{})
+
+	me, err := client.RetrieveMe(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, UserBot, me.Type)
+	require.NotNil(t, me.Bot)
+	assert.Equal(t, "acme", me.Bot.WorkspaceName)
+	assert.Equal(t, &BotOwner{Type: BotOwnerWorkspace, Workspace: true}, me.Bot.Owner)
+}